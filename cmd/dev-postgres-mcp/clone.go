@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// newDatabaseCloneCommand creates the database clone command.
+func newDatabaseCloneCommand() *cobra.Command {
+	var ttl time.Duration
+	var persistent bool
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "clone <instance-id>",
+		Short: "Copy an instance into a new independent instance",
+		Long: `Create a new database instance of the same type and version as the source
+and copy the source's data into it.
+
+The clone gets its own container, port and password, and is independent of
+the source from then on.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			clone, err := unifiedManager.CloneInstance(ctx, args[0], types.CloneInstanceOptions{
+				TTL:        ttl,
+				Persistent: persistent,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to clone instance: %w", err)
+			}
+
+			output, err := json.MarshalIndent(clone, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal instance to JSON: %w", err)
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", 0, "Time-to-live of the clone (e.g. 30m, 2h); 0 means no limit")
+	cmd.Flags().BoolVar(&persistent, "persistent", false, "Store the clone's data in a managed volume")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
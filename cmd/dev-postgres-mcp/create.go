@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// Exit codes of the database create command besides 0 (healthy) and 1 (error).
+const (
+	exitCodeUnhealthy        = 2
+	exitCodeInitScriptFailed = 3
+)
+
+// newDatabaseCreateCommand creates the database create command.
+func newDatabaseCreateCommand() *cobra.Command {
+	var opts types.CreateInstanceOptions
+	var dbType string
+	var format string
+	var wait bool
+	var noWait bool
+	var memory string
+	var shmSize string
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new database instance",
+		Long: `Create a new database instance, the same way the create_database_instance
+MCP tool does.
+
+By default the command waits until the instance is healthy. With --no-wait it
+returns as soon as the container has started.
+
+Output formats:
+  • table - human-readable details (default)
+  • json  - the full instance as JSON
+  • env   - shell variable assignments, e.g. for eval "$(... --format env)"
+  • dsn   - only the connection string
+
+Exit codes:
+  • 0 - the instance is healthy (or started, with --no-wait)
+  • 1 - the instance could not be created
+  • 2 - the instance did not become healthy and was removed
+  • 3 - the instance was created but an init script failed`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts.Type = types.DatabaseType(dbType)
+			opts.NoWait = noWait || !wait
+			if err := parseSizeFlags(map[string]sizeFlag{
+				"memory":   {memory, &opts.Memory},
+				"shm-size": {shmSize, &opts.ShmSize},
+			}); err != nil {
+				return err
+			}
+
+			switch format {
+			case "table", "json", "env", "dsn":
+			default:
+				return fmt.Errorf("unsupported format: %s", format)
+			}
+
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			// Ctrl+C aborts the creation, which removes the half-created container
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			instance, err := unifiedManager.CreateInstance(ctx, opts)
+			if err != nil {
+				if errors.Is(err, types.ErrInstanceUnhealthy) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to create instance: %v\n", err)
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return &exitCodeError{code: exitCodeUnhealthy}
+				}
+				return fmt.Errorf("failed to create instance: %w", err)
+			}
+
+			if err := printCreatedInstance(instance, format); err != nil {
+				return err
+			}
+
+			for _, script := range instance.InitScripts {
+				if script.Status == types.InitScriptFailed {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Error: init script %s failed: %s\n", script.Source, script.Error)
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return &exitCodeError{code: exitCodeInitScriptFailed}
+				}
+			}
+			return nil
+		},
+	}
+
+	engineTypeFlag(cmd, &dbType, string(types.DatabaseTypePostgreSQL), "Database type")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "Database version (defaults vary by type)")
+	cmd.Flags().StringVar(&opts.Database, "database", "", "Database name (defaults vary by type)")
+	cmd.Flags().StringVar(&opts.Username, "username", "", "Database username (defaults vary by type)")
+	cmd.Flags().StringVar(&opts.Password, "password", "", "Database password (auto-generated if not provided)")
+	cmd.Flags().DurationVar(&opts.TTL, "ttl", 0, "Time-to-live after which a running MCP server drops the instance (e.g. 30m, 2h); 0 means no limit")
+	cmd.Flags().BoolVar(&opts.Persistent, "persistent", false, "Store data in a managed volume that can be detached and reattached")
+	cmd.Flags().StringVar(&memory, "memory", "", "Container memory limit, e.g. 256m or 2g (default: 512m, or what the engine needs)")
+	cmd.Flags().Float64Var(&opts.CPUs, "cpus", 0, "Number of CPUs the container may use, e.g. 0.5 (default: 1)")
+	cmd.Flags().StringVar(&shmSize, "shm-size", "", "Size of /dev/shm, e.g. 256m (default: 64m)")
+	cmd.Flags().BoolVar(&opts.Tmpfs, "tmpfs", false, "Keep the data directory in memory on a tmpfs mount (counts towards --memory)")
+	cmd.Flags().StringArrayVar(&opts.InitScripts, "init-script", nil, "SQL applied once healthy: inline SQL, a file, a directory of *.sql files, or a URL (repeatable)")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json, env, dsn)")
+	cmd.Flags().BoolVar(&wait, "wait", true, "Wait for the instance to become healthy")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Return as soon as the container has started (same as --wait=false)")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// printCreatedInstance prints a newly created instance in the given format.
+func printCreatedInstance(instance *types.DatabaseInstance, format string) error {
+	switch format {
+	case "json":
+		output, err := json.MarshalIndent(instance, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal instance to JSON: %w", err)
+		}
+		fmt.Println(string(output))
+	case "env":
+		vars := []struct{ name, value string }{
+			{"DB_INSTANCE_ID", instance.ID},
+			{"DB_TYPE", string(instance.Type)},
+			{"DB_HOST", "localhost"},
+			{"DB_PORT", fmt.Sprint(instance.Port)},
+			{"DB_NAME", instance.Database},
+			{"DB_USER", instance.Username},
+			{"DB_PASSWORD", instance.Password},
+			{"DATABASE_URL", instance.DSN},
+		}
+		for _, v := range vars {
+			fmt.Printf("%s=%s\n", v.name, shellQuote(v.value))
+		}
+	case "dsn":
+		fmt.Println(instance.DSN)
+	default:
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "INSTANCE ID\t%s\n", instance.ID)
+		fmt.Fprintf(w, "TYPE\t%s\n", instance.Type)
+		fmt.Fprintf(w, "VERSION\t%s\n", instance.Version)
+		fmt.Fprintf(w, "PORT\t%d\n", instance.Port)
+		fmt.Fprintf(w, "DATABASE\t%s\n", instance.Database)
+		fmt.Fprintf(w, "USERNAME\t%s\n", instance.Username)
+		fmt.Fprintf(w, "PASSWORD\t%s\n", instance.Password)
+		fmt.Fprintf(w, "DSN\t%s\n", instance.DSN)
+		fmt.Fprintf(w, "STATUS\t%s\n", instance.Status)
+		if instance.ExpiresAt != nil {
+			fmt.Fprintf(w, "EXPIRES AT\t%s\n", instance.ExpiresAt.Format(time.RFC3339))
+		}
+		if instance.Volume != "" {
+			fmt.Fprintf(w, "VOLUME\t%s\n", instance.Volume)
+		}
+		if instance.Resources != nil {
+			fmt.Fprintf(w, "RESOURCES\t%s\n", instance.Resources)
+		}
+		for _, script := range instance.InitScripts {
+			fmt.Fprintf(w, "INIT SCRIPT\t%s (%s)\n", script.Source, script.Status)
+		}
+		w.Flush()
+	}
+
+	return nil
+}
+
+// sizeFlag is a size flag value, e.g. "512m", and the byte count it is parsed into.
+type sizeFlag struct {
+	value string
+	dst   *int64
+}
+
+// parseSizeFlags parses the non-empty size flags, keyed by flag name, into their destinations.
+func parseSizeFlags(flags map[string]sizeFlag) error {
+	for name, flag := range flags {
+		if flag.value == "" {
+			continue
+		}
+		size, err := units.RAMInBytes(flag.value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s %q: %w", name, flag.value, err)
+		}
+		*flag.dst = size
+	}
+	return nil
+}
+
+// shellQuote quotes a value for POSIX shells.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
+)
+
+// newDatabaseCredentialsCommand creates the database credentials command group.
+func newDatabaseCredentialsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Manage stored instance credentials",
+		Long: `Manage the local credential store used to persist instance passwords.
+
+Passwords are stored in an AES-GCM encrypted file in the user config directory
+(override with DEV_POSTGRES_MCP_CONFIG_DIR), keyed by instance ID. Entries are
+written when an instance is created and purged when it is dropped.`,
+	}
+
+	cmd.AddCommand(newCredentialsRotateKeyCommand())
+	cmd.AddCommand(newCredentialsPruneCommand())
+
+	return cmd
+}
+
+// newCredentialsRotateKeyCommand creates the database credentials rotate-key command.
+func newCredentialsRotateKeyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt stored credentials with a new key",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			credentials, err := store.NewDefaultCredentialStore()
+			if err != nil {
+				return fmt.Errorf("failed to open credential store: %w", err)
+			}
+
+			if err := credentials.RotateKey(); err != nil {
+				return fmt.Errorf("failed to rotate credential store key: %w", err)
+			}
+
+			fmt.Printf("Credential store key rotated (%s).\n", credentials.Dir())
+			return nil
+		},
+	}
+}
+
+// newCredentialsPruneCommand creates the database credentials prune command.
+func newCredentialsPruneCommand() *cobra.Command {
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove stored credentials for instances that no longer exist",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runCredentialsPrune(startPort, endPort)
+		},
+	}
+
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// runCredentialsPrune removes credential store entries for instances that no longer exist.
+func runCredentialsPrune(startPort, endPort int) error {
+	credentials, err := store.NewDefaultCredentialStore()
+	if err != nil {
+		return fmt.Errorf("failed to open credential store: %w", err)
+	}
+
+	ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	instances, err := unifiedManager.ListInstances(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	keep := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		keep[instance.ID] = true
+	}
+
+	removed, err := credentials.Prune(keep)
+	if err != nil {
+		return fmt.Errorf("failed to prune credential store: %w", err)
+	}
+
+	for _, id := range removed {
+		fmt.Printf("Removed credentials for %s\n", id)
+	}
+	fmt.Printf("Pruned %d credential store entries.\n", len(removed))
+	return nil
+}
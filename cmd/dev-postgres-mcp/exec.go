@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// exitCodeError makes the process exit with the given code without printing an error,
+// e.g. to pass through the exit code of a command run inside a container.
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.code)
+}
+
+// newDatabaseExecCommand creates the database exec command.
+func newDatabaseExecCommand() *cobra.Command {
+	var user string
+	var workdir string
+	var env []string
+	var timeout time.Duration
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "exec <instance-id> -- <command> [args...]",
+		Short: "Run a command inside an instance's container",
+		Long: `Run a command inside a database instance's container, like "docker exec".
+
+Standard output and error are streamed to the terminal, standard input is
+forwarded when it is not a terminal, and the command's exit code becomes the
+exit code of this command. The engine's client password is provided through
+the environment (PGPASSWORD or MYSQL_PWD), so client tools connect without
+prompting.
+
+Examples:
+  dev-postgres-mcp database exec <instance-id> -- psql -U postgres -c 'SELECT version()'
+  dev-postgres-mcp database exec <instance-id> -- pg_dump -U postgres postgres > dump.sql
+  dev-postgres-mcp database exec <instance-id> -- mysql -u root app < seed.sql`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command := execCommandArgs(args[1:])
+			if len(command) == 0 {
+				return fmt.Errorf("a command to run is required after the instance ID")
+			}
+
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			opts := types.ExecOptions{
+				Cmd:        command,
+				Env:        env,
+				User:       user,
+				WorkingDir: workdir,
+				Stdout:     os.Stdout,
+				Stderr:     os.Stderr,
+				Timeout:    timeout,
+			}
+			if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+				opts.Stdin = os.Stdin
+			}
+
+			exitCode, err := unifiedManager.ExecInInstance(ctx, args[0], opts)
+			if err != nil {
+				return fmt.Errorf("failed to run command: %w", err)
+			}
+
+			if exitCode != 0 {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &exitCodeError{code: exitCode}
+			}
+			return nil
+		},
+	}
+
+	// Everything after the instance ID belongs to the command, even without "--"
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().StringVarP(&user, "user", "u", "", "User to run the command as")
+	cmd.Flags().StringVarP(&workdir, "workdir", "w", "", "Working directory inside the container")
+	cmd.Flags().StringArrayVarP(&env, "env", "e", nil, "Additional environment variables (KEY=value)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Stop waiting for the command after this long (0 means no timeout)")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// execCommandArgs returns the command to run from the arguments after the instance ID.
+// With interspersed flags disabled, cobra keeps the "--" separator in the arguments.
+func execCommandArgs(args []string) []string {
+	if len(args) > 0 && args[0] == "--" {
+		return args[1:]
+	}
+	return args
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newDatabaseStopCommand creates the database stop command.
+func newDatabaseStopCommand() *cobra.Command {
+	return newLifecycleCommand("stop", "Stop a database instance, keeping its data and port",
+		`Stop a database instance's container to free CPU and memory.
+
+The container, its data and its port are kept, so the instance can be brought
+back with "database start".`,
+		"stopped",
+		func(ctx context.Context, m lifecycleManager, id string) error { return m.StopInstance(ctx, id) })
+}
+
+// newDatabaseStartCommand creates the database start command.
+func newDatabaseStartCommand() *cobra.Command {
+	return newLifecycleCommand("start", "Start a stopped database instance",
+		"Start a stopped database instance and wait for it to become healthy.",
+		"started",
+		func(ctx context.Context, m lifecycleManager, id string) error { return m.StartInstance(ctx, id) })
+}
+
+// newDatabaseRestartCommand creates the database restart command.
+func newDatabaseRestartCommand() *cobra.Command {
+	return newLifecycleCommand("restart", "Restart a database instance",
+		"Stop and start a database instance and wait for it to become healthy.",
+		"restarted",
+		func(ctx context.Context, m lifecycleManager, id string) error { return m.RestartInstance(ctx, id) })
+}
+
+// lifecycleManager is the subset of the unified manager used by the lifecycle commands.
+type lifecycleManager interface {
+	StopInstance(ctx context.Context, id string) error
+	StartInstance(ctx context.Context, id string) error
+	RestartInstance(ctx context.Context, id string) error
+}
+
+// newLifecycleCommand creates a command that applies a lifecycle operation to one instance.
+func newLifecycleCommand(use, short, long, done string, operation func(context.Context, lifecycleManager, string) error) *cobra.Command {
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   use + " <instance-id>",
+		Short: short,
+		Long:  long,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			instance, err := unifiedManager.GetInstance(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to find instance: %w", err)
+			}
+
+			if err := operation(ctx, unifiedManager, instance.ID); err != nil {
+				return fmt.Errorf("failed to %s instance: %w", use, err)
+			}
+
+			fmt.Printf("Database instance %s (%s) %s on port %d.\n", instance.ID, instance.Type, done, instance.Port)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
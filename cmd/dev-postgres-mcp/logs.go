@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// newDatabaseLogsCommand creates the database logs command.
+func newDatabaseLogsCommand() *cobra.Command {
+	var follow bool
+	var since string
+	var tail string
+	var timestamps bool
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "logs <instance-id>",
+		Short: "Show the server logs of a database instance",
+		Long: `Show the server logs of a database instance, like docker logs.
+
+The server's standard output and standard error are written to this command's
+standard output and standard error. With --follow, new output is streamed until
+the instance stops or the command is interrupted (Ctrl+C).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+			defer stop()
+
+			if err := unifiedManager.StreamLogs(ctx, args[0], types.LogOptions{
+				Follow:     follow,
+				Since:      since,
+				Tail:       tail,
+				Timestamps: timestamps,
+				Stdout:     cmd.OutOrStdout(),
+				Stderr:     cmd.ErrOrStderr(),
+			}); err != nil {
+				return fmt.Errorf("failed to get instance logs: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Follow log output")
+	cmd.Flags().StringVar(&since, "since", "", "Show logs since a timestamp (e.g. 2025-01-02T13:23:37Z) or relative duration (e.g. 10m)")
+	cmd.Flags().StringVarP(&tail, "tail", "n", "all", "Number of lines to show from the end of the logs")
+	cmd.Flags().BoolVarP(&timestamps, "timestamps", "t", false, "Show timestamps")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
@@ -0,0 +1,41 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// notifyResize reports the terminal size as [height, width] whenever it changes.
+// The returned function stops the notifications.
+func notifyResize(fd int) (<-chan [2]uint, func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+
+	sizes := make(chan [2]uint, 1)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				if width, height, err := term.GetSize(fd); err == nil {
+					select {
+					case sizes <- [2]uint{uint(height), uint(width)}:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	return sizes, func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+// notifyResize is a no-op on Windows, which has no SIGWINCH; the terminal keeps its initial size.
+func notifyResize(_ int) (<-chan [2]uint, func()) {
+	return nil, func() {}
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"text/tabwriter"
 	"time"
@@ -22,6 +25,17 @@ import (
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute(args ...string) {
+	var enginesConfig string
+	var profilesLoaded bool
+	// loadProfiles registers the engine profiles once, from either the pre-run or the help
+	loadProfiles := func() error {
+		if profilesLoaded {
+			return nil
+		}
+		profilesLoaded = true
+		return loadEngineProfiles(enginesConfig)
+	}
+
 	var rootCmd = &cobra.Command{
 		Use:   "dev-postgres-mcp",
 		Short: "MCP server for managing ephemeral database instances",
@@ -29,12 +43,15 @@ func Execute(args ...string) {
 tools for creating, managing, and accessing ephemeral database instances
 running in Docker containers.
 
-Supports PostgreSQL, MySQL, and MariaDB databases. Each instance is completely 
+Supports PostgreSQL, MySQL, MariaDB, Redis, Valkey, MongoDB, ClickHouse,
+SQL Server, and CockroachDB.
+Each instance is completely
 ephemeral and can be created and destroyed on demand, making it perfect for 
 development, testing, and experimentation.
 
 FEATURES:
-  • Create ephemeral PostgreSQL, MySQL, and MariaDB instances in Docker containers
+  • Create ephemeral PostgreSQL, MySQL, MariaDB, Redis, Valkey, MongoDB,
+    ClickHouse, SQL Server, and CockroachDB instances in Docker containers
   • Dynamic port allocation to prevent conflicts
   • Support for multiple database versions
   • Superuser access with auto-generated credentials
@@ -59,11 +76,31 @@ EXAMPLES:
 
 Use "dev-postgres-mcp [command] --help" for detailed information about each command.`,
 		Args: cobra.NoArgs, // Disallow unknown subcommands
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			if err := loadProfiles(); err != nil {
+				return err
+			}
+			describeEngineTypes(cmd)
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
 	}
 
+	rootCmd.PersistentFlags().StringVar(&enginesConfig, "engines-config", "",
+		fmt.Sprintf("YAML or JSON file declaring custom database engines (default: $%s)", types.EnginesConfigEnv))
+
+	// --help skips PersistentPreRunE, so the profiles are also loaded before rendering help
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if err := loadProfiles(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		}
+		describeEngineTypes(cmd)
+		defaultHelp(cmd, args)
+	})
+
 	if len(args) > 0 {
 		rootCmd.SetArgs(args)
 	}
@@ -75,10 +112,53 @@ Use "dev-postgres-mcp [command] --help" for detailed information about each comm
 
 	err := rootCmd.Execute()
 	if err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code) //revive:disable-line:deep-exit
+		}
 		os.Exit(1) //revive:disable-line:deep-exit
 	}
 }
 
+// loadEngineProfiles registers the engine profiles declared in path, or in the file named by
+// the engines config environment variable if path is empty.
+func loadEngineProfiles(path string) error {
+	if path == "" {
+		path = os.Getenv(types.EnginesConfigEnv)
+	}
+	if path == "" {
+		return nil
+	}
+
+	if err := types.RegisterEngineProfiles(path); err != nil {
+		return err
+	}
+
+	slog.Info("Loaded engine profiles", "path", path, "engines", types.EngineTypeNames())
+	return nil
+}
+
+// engineTypesAnnotation marks the --type flags whose usage lists the registered engines.
+const engineTypesAnnotation = "engine-types"
+
+// engineTypeFlag defines a --type flag on cmd. The registered engines are appended to its usage
+// by describeEngineTypes, once the engine profiles have been loaded.
+func engineTypeFlag(cmd *cobra.Command, p *string, value, usage string) {
+	cmd.Flags().StringVar(p, "type", value, usage)
+	_ = cmd.Flags().SetAnnotation("type", engineTypesAnnotation, []string{usage})
+}
+
+// describeEngineTypes lists the registered engines in the usage of the --type flag of cmd.
+func describeEngineTypes(cmd *cobra.Command) {
+	flag := cmd.Flags().Lookup("type")
+	if flag == nil {
+		return
+	}
+	if usage, ok := flag.Annotations[engineTypesAnnotation]; ok {
+		flag.Usage = fmt.Sprintf("%s (%s)", usage[0], strings.Join(types.EngineTypeNames(), ", "))
+	}
+}
+
 // newMCPCommand creates the mcp command group.
 func newMCPCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -96,6 +176,22 @@ func newMCPCommand() *cobra.Command {
 func newMCPServeCommand() *cobra.Command {
 	var startPort int
 	var endPort int
+	var ownerID string
+	var cleanupPolicy string
+	var transport string
+	var listenAddr string
+	var authTokensFile string
+	var allowUnauthenticated bool
+	var reapInterval time.Duration
+	var idleTimeout time.Duration
+	var resources types.ResourcePolicy
+	var defaultMemory string
+	var maxMemory string
+	var maxShmSize string
+	var quotas types.QuotaPolicy
+	var maxInstancesPerType map[string]int
+	var maxTotalMemory string
+	var initScripts types.InitScriptPolicy
 
 	cmd := &cobra.Command{
 		Use:   "serve",
@@ -103,39 +199,172 @@ func newMCPServeCommand() *cobra.Command {
 		Long: `Start the Model Context Protocol server for database instance management.
 
 This command starts the MCP server that provides tools for managing database
-instances (PostgreSQL, MySQL, MariaDB). The server communicates using the Model 
-Context Protocol over stdio, making it compatible with MCP clients like Augment Code.
+instances (PostgreSQL, MySQL, MariaDB, Redis, Valkey, MongoDB, ClickHouse, SQL Server, CockroachDB). The server communicates using the Model 
+Context Protocol, making it compatible with MCP clients like Augment Code.
+
+By default the server talks to a single client over stdio. With --transport sse
+or --transport http it listens on --listen instead, so that one long-lived
+server can be shared by several editors and agents:
+  • sse  - server-sent events at /sse, with messages posted to /message
+  • http - streamable HTTP at /mcp
+Each client connection is a separate MCP session.
+
+Clients of the network transports authenticate with static API tokens, sent as
+"Authorization: Bearer <token>" or in the X-API-Key header. Tokens are read from
+--auth-tokens-file and from the DEV_POSTGRES_MCP_AUTH_TOKENS environment
+variable (principal:token[:admin],...). Each token maps to a principal: the
+instances, volumes and snapshots a client creates are labelled with its principal,
+and clients only see and manage their own principal's resources unless their
+token has admin scope. Without tokens the server refuses to listen on a
+non-loopback address unless --allow-unauthenticated is set. Requests from other
+web origins are rejected. Clients of the network transports may only pass inline
+SQL as init scripts; host paths and URLs must lie under --init-script-allowed-dirs
+or --init-script-allowed-hosts.
 
 The server provides the following unified tools:
   • create_database_instance - Create a new database instance
   • list_database_instances - List all running instances
   • get_database_instance - Get details of a specific instance
   • drop_database_instance - Remove a database instance
+  • stop_database_instance - Stop an instance, keeping its data and port
+  • start_database_instance - Start a stopped instance
+  • restart_database_instance - Restart an instance
   • health_check_database - Check instance health
-
-The server will run until interrupted (Ctrl+C) and will automatically clean up
-all managed database instances on shutdown.`,
+  • list_database_volumes - List data volumes of persistent instances
+  • detach_database_instance - Remove a persistent instance but keep its data
+  • attach_database_volume - Recreate an instance from a detached volume
+  • delete_database_volume - Delete a detached data volume
+  • clone_database_instance - Copy an instance into a new independent instance
+  • snapshot_database_instance - Take a logical snapshot of an instance
+  • restore_database_instance - Restore a snapshot into a new or existing instance
+  • list_database_snapshots - List stored snapshots
+  • delete_database_snapshot - Delete a stored snapshot
+  • exec_in_instance - Run a command inside an instance's container
+  • get_instance_logs - Return recent server log lines, filtered by level or pattern
+  • execute_sql - Execute a SQL statement against an instance
+
+Every instance is also published as a dbinstance://<id> resource with its
+connection information and a summary of its tables, and the columns of each
+table are available as dbinstance://<id>/tables/<name>. Clients are notified
+when instances are created, dropped or change health.
+
+Available prompts:
+  • provision_test_database - Test a migration on a throwaway or cloned database
+  • diagnose_slow_query - Diagnose a slow query with the engine's query plans
+  • compare_schemas - Compare the schemas of two instances and plan a migration
+
+The server will run until interrupted (Ctrl+C). Every instance it creates is
+labelled with the server's owner ID, and on shutdown the --cleanup-policy flag
+decides what is removed:
+  • owned - only instances created by this server (default)
+  • all   - every managed instance, including those of other servers
+  • none  - nothing; instances keep running after the server exits
+Persistent instances selected for cleanup are detached rather than dropped, so
+their data volumes survive and can be reattached with "database volume attach".
+
+Pass a stable --owner-id to let a restarted server adopt the instances created
+by its predecessor.
+
+While running, the server periodically drops instances whose ttl has expired.
+With --idle-timeout, it also drops instances it owns that have had no client
+connections for that long.
+
+Each instance gets 512 MB of memory (or more if its engine needs it) and 1 CPU
+unless it requests otherwise. The --default-* flags change these defaults and
+the --max-* flags cap what an instance may request.
+
+Quotas stop runaway clients from exhausting the host: --max-instances and
+--max-instances-per-type count all managed instances (including stopped ones),
+--max-total-memory sums the memory limits of running instances, and
+--max-instances-per-session counts the instances each MCP session created.
+Creating an instance that would exceed a quota fails with an error telling the
+client to drop an instance first.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return runMCPServe(startPort, endPort)
+			if err := parseSizeFlags(map[string]sizeFlag{
+				"default-memory":   {defaultMemory, &resources.DefaultMemory},
+				"max-memory":       {maxMemory, &resources.MaxMemory},
+				"max-shm-size":     {maxShmSize, &resources.MaxShmSize},
+				"max-total-memory": {maxTotalMemory, &quotas.MaxTotalMemory},
+			}); err != nil {
+				return err
+			}
+			for dbType, limit := range maxInstancesPerType {
+				if quotas.MaxInstancesPerType == nil {
+					quotas.MaxInstancesPerType = make(map[types.DatabaseType]int)
+				}
+				quotas.MaxInstancesPerType[types.DatabaseType(dbType)] = limit
+			}
+			authTokens, err := loadAuthTokens(authTokensFile)
+			if err != nil {
+				return err
+			}
+			return runMCPServe(mcp.ServerConfig{
+				StartPort:            startPort,
+				EndPort:              endPort,
+				Transport:            transport,
+				ListenAddr:           listenAddr,
+				AuthTokens:           authTokens,
+				AllowUnauthenticated: allowUnauthenticated,
+				OwnerID:              ownerID,
+				CleanupPolicy:        types.CleanupPolicy(cleanupPolicy),
+				ReapInterval:         reapInterval,
+				IdleTimeout:          idleTimeout,
+				Resources:            resources,
+				Quotas:               quotas,
+				InitScripts:          initScripts,
+			})
 		},
 	}
 
 	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
 	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+	cmd.Flags().StringVar(&transport, "transport", mcp.TransportStdio, "Transport to serve clients over (stdio, sse, http)")
+	cmd.Flags().StringVar(&listenAddr, "listen", mcp.DefaultListenAddr, "Address the sse and http transports listen on")
+	cmd.Flags().StringVar(&authTokensFile, "auth-tokens-file", "", "YAML or JSON file with the API tokens clients of the sse and http transports authenticate with")
+	cmd.Flags().BoolVar(&allowUnauthenticated, "allow-unauthenticated", false, "Serve the sse and http transports without auth tokens on a non-loopback address")
+	cmd.Flags().StringVar(&ownerID, "owner-id", "", "Owner ID recorded on created instances (random per process if empty)")
+	cmd.Flags().StringVar(&cleanupPolicy, "cleanup-policy", string(types.CleanupPolicyOwned), "Instances to remove on shutdown (all, owned, none)")
+	cmd.Flags().DurationVar(&reapInterval, "reap-interval", mcp.DefaultReapInterval, "How often to check for expired and idle instances")
+	cmd.Flags().DurationVar(&idleTimeout, "idle-timeout", 0, "Drop owned instances without client connections for this long (0 disables)")
+	cmd.Flags().StringVar(&defaultMemory, "default-memory", "", "Memory limit of instances that do not request one, e.g. 1g (default: 512m)")
+	cmd.Flags().StringVar(&maxMemory, "max-memory", "", "Largest memory limit an instance may request, e.g. 4g (default: no maximum)")
+	cmd.Flags().Float64Var(&resources.DefaultCPUs, "default-cpus", 0, "CPU limit of instances that do not request one (default: 1)")
+	cmd.Flags().Float64Var(&resources.MaxCPUs, "max-cpus", 0, "Largest CPU limit an instance may request (default: no maximum)")
+	cmd.Flags().StringVar(&maxShmSize, "max-shm-size", "", "Largest /dev/shm size an instance may request, e.g. 1g (default: no maximum)")
+	cmd.Flags().IntVar(&quotas.MaxInstances, "max-instances", 0, "Maximum number of managed instances (0 disables)")
+	cmd.Flags().StringToIntVar(&maxInstancesPerType, "max-instances-per-type", nil, "Maximum number of managed instances per type, e.g. postgresql=5,sqlserver=1")
+	cmd.Flags().StringVar(&maxTotalMemory, "max-total-memory", "", "Maximum sum of the memory limits of running instances, e.g. 8g (default: no maximum)")
+	cmd.Flags().IntVar(&quotas.MaxInstancesPerSession, "max-instances-per-session", 0, "Maximum number of instances each MCP session may create (0 disables)")
+	cmd.Flags().StringSliceVar(&initScripts.AllowedDirs, "init-script-allowed-dirs", nil, "Host directories clients of the sse and http transports may load init scripts from")
+	cmd.Flags().StringSliceVar(&initScripts.AllowedHosts, "init-script-allowed-hosts", nil, "Hosts clients of the sse and http transports may fetch init script URLs from")
 
 	return cmd
 }
 
-// runMCPServe runs the MCP server.
-func runMCPServe(startPort, endPort int) error {
-	// Create MCP server
-	config := mcp.ServerConfig{
-		Name:      "dev-postgres-mcp",
-		Version:   "1.0.0",
-		StartPort: startPort,
-		EndPort:   endPort,
-		LogLevel:  "info",
+// loadAuthTokens returns the API tokens in path, if set, and in the auth tokens environment variable.
+func loadAuthTokens(path string) ([]mcp.APIToken, error) {
+	var tokens []mcp.APIToken
+	if path != "" {
+		fileTokens, err := mcp.LoadAuthTokens(path)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, fileTokens...)
 	}
+
+	envTokens, err := mcp.ParseAuthTokens(os.Getenv(mcp.AuthTokensEnv))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", mcp.AuthTokensEnv, err)
+	}
+	return append(tokens, envTokens...), nil
+}
+
+// runMCPServe runs the MCP server. The configuration is validated by mcp.NewServer.
+func runMCPServe(config mcp.ServerConfig) error {
+	// Create MCP server
+	config.Name = "dev-postgres-mcp"
+	config.Version = "1.0.0"
+	config.LogLevel = "info"
 	server, err := mcp.NewServer(config)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -173,13 +402,24 @@ func newDatabaseCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "database",
 		Short:   "Database instance management commands",
-		Long:    "Commands for managing database instances (PostgreSQL, MySQL, MariaDB) outside of the MCP protocol.",
+		Long:    "Commands for managing database instances (PostgreSQL, MySQL, MariaDB, Redis, Valkey, MongoDB, ClickHouse, SQL Server, CockroachDB) outside of the MCP protocol.",
 		Aliases: []string{"db"},
 	}
 
+	cmd.AddCommand(newDatabaseCreateCommand())
 	cmd.AddCommand(newDatabaseListCommand())
 	cmd.AddCommand(newDatabaseGetCommand())
 	cmd.AddCommand(newDatabaseDropCommand())
+	cmd.AddCommand(newDatabaseStopCommand())
+	cmd.AddCommand(newDatabaseStartCommand())
+	cmd.AddCommand(newDatabaseRestartCommand())
+	cmd.AddCommand(newDatabaseCloneCommand())
+	cmd.AddCommand(newDatabaseExecCommand())
+	cmd.AddCommand(newDatabaseShellCommand())
+	cmd.AddCommand(newDatabaseLogsCommand())
+	cmd.AddCommand(newDatabaseCredentialsCommand())
+	cmd.AddCommand(newDatabaseVolumeCommand())
+	cmd.AddCommand(newDatabaseSnapshotCommand())
 
 	return cmd
 }
@@ -205,7 +445,7 @@ func newDatabaseListCommand() *cobra.Command {
 
 This command shows details about each instance including:
   • Instance ID
-  • Database Type (PostgreSQL, MySQL, MariaDB)
+  • Database Type (e.g. PostgreSQL, MySQL, Redis)
   • Container ID
   • Port number
   • Database name
@@ -219,7 +459,7 @@ This command shows details about each instance including:
 	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
 	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
 	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
-	cmd.Flags().StringVar(&dbType, "type", "", "Filter by database type (postgresql, mysql, mariadb)")
+	engineTypeFlag(cmd, &dbType, "", "Filter by database type")
 
 	return cmd
 }
@@ -285,6 +525,29 @@ WARNING: This action is irreversible. All data in the instance will be lost.`,
 	return cmd
 }
 
+// connectUnifiedManager connects to Docker and creates a unified database manager.
+// The returned cleanup function closes the Docker connection.
+func connectUnifiedManager(startPort, endPort int) (context.Context, *database.UnifiedManager, func(), error) {
+	// Create Docker manager
+	dockerMgr, err := docker.NewManager(startPort, endPort)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create Docker manager: %w", err)
+	}
+
+	// Test Docker connection
+	ctx := context.Background()
+	if err := dockerMgr.Ping(ctx); err != nil {
+		dockerMgr.Close()
+		return nil, nil, nil, fmt.Errorf("Docker daemon is not accessible: %w", err)
+	}
+
+	cleanup := func() {
+		dockerMgr.Close()
+	}
+
+	return ctx, database.NewUnifiedManager(dockerMgr), cleanup, nil
+}
+
 // runDatabaseList lists all database instances.
 func runDatabaseList(format string, startPort, endPort int, dbType string) error {
 	// Create Docker manager
@@ -308,7 +571,7 @@ func runDatabaseList(format string, startPort, endPort int, dbType string) error
 	if dbType != "" {
 		dbTypeEnum := types.DatabaseType(dbType)
 		if !dbTypeEnum.IsValid() {
-			return fmt.Errorf("invalid database type: %s", dbType)
+			return fmt.Errorf("invalid database type: %s (supported: %s)", dbType, strings.Join(types.EngineTypeNames(), ", "))
 		}
 		instances, err = unifiedManager.ListInstancesByType(ctx, dbTypeEnum)
 	} else {
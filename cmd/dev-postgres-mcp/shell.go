@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// newDatabaseShellCommand creates the database shell command.
+func newDatabaseShellCommand() *cobra.Command {
+	var inContainer bool
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "shell <instance-id>",
+		Short: "Open an interactive client session on an instance",
+		Long: `Open an interactive psql, mysql or mariadb session on a database instance.
+
+The instance ID may be abbreviated to any unique prefix. If the engine's client
+is installed on the host, it connects through the instance's port; otherwise
+the client inside the container is used with a terminal attached. Use
+--container to always use the client inside the container.
+
+Stored credentials are passed through the environment, so no password needs
+to be typed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			shell, err := unifiedManager.ShellCommand(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open shell: %w", err)
+			}
+
+			if shell.Instance.Password == "" {
+				fmt.Fprintf(os.Stderr, "Password for instance %s is not stored; the client may prompt for it.\n", shell.Instance.ID)
+			}
+
+			var exitCode int
+			if path, ok := hostClient(shell, inContainer); ok {
+				exitCode, err = runHostShell(path, shell)
+			} else {
+				exitCode, err = runContainerShell(ctx, cmd, unifiedManager, shell)
+			}
+			if err != nil {
+				return err
+			}
+
+			if exitCode != 0 {
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &exitCodeError{code: exitCode}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&inContainer, "container", false, "Always use the client inside the container")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// hostClient returns the path of the host client for a shell, if one should be used.
+func hostClient(shell *types.ShellCommand, inContainer bool) (string, bool) {
+	if inContainer || len(shell.Host) == 0 {
+		return "", false
+	}
+
+	path, err := exec.LookPath(shell.Host[0])
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// runHostShell runs the host client with the terminal attached and returns its exit code.
+func runHostShell(path string, shell *types.ShellCommand) (int, error) {
+	client := exec.Command(path, shell.Host[1:]...)
+	client.Env = append(os.Environ(), shell.HostEnv...)
+	client.Stdin = os.Stdin
+	client.Stdout = os.Stdout
+	client.Stderr = os.Stderr
+
+	if err := client.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return -1, fmt.Errorf("failed to run %s: %w", shell.Host[0], err)
+	}
+	return 0, nil
+}
+
+// runContainerShell runs the client inside the container with a TTY attached and returns its exit code.
+func runContainerShell(ctx context.Context, cmd *cobra.Command, manager *database.UnifiedManager, shell *types.ShellCommand) (int, error) {
+	opts := types.ExecOptions{
+		Cmd:    shell.Container,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		opts.Tty = true
+
+		if width, height, err := term.GetSize(fd); err == nil {
+			opts.ConsoleSize = &[2]uint{uint(height), uint(width)}
+		}
+
+		resize, stop := notifyResize(fd)
+		defer stop()
+		opts.Resize = resize
+
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return -1, fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer func() {
+			if err := term.Restore(fd, state); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "failed to restore terminal: %v\n", err)
+			}
+		}()
+	}
+
+	exitCode, err := manager.ExecInInstance(ctx, shell.Instance.ID, opts)
+	if err != nil {
+		return -1, fmt.Errorf("failed to run %s in container: %w", shell.Container[0], err)
+	}
+	return exitCode, nil
+}
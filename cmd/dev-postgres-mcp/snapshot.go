@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// newDatabaseSnapshotCommand creates the database snapshot command group.
+func newDatabaseSnapshotCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take and restore instance snapshots",
+		Long: `Take and restore logical snapshots of database instances.
+
+A snapshot is a SQL dump of the instance's database taken with the engine's
+own dump tool (pg_dump, mysqldump, mariadb-dump) inside the container. Snapshots
+are kept in the user config directory (override with DEV_POSTGRES_MCP_CONFIG_DIR)
+and can be restored into the same instance as an undo point, or into a new one.`,
+	}
+
+	cmd.AddCommand(newSnapshotCreateCommand())
+	cmd.AddCommand(newSnapshotListCommand())
+	cmd.AddCommand(newSnapshotRestoreCommand())
+	cmd.AddCommand(newSnapshotDeleteCommand())
+
+	return cmd
+}
+
+// newSnapshotCreateCommand creates the database snapshot create command.
+func newSnapshotCreateCommand() *cobra.Command {
+	var name string
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "create <instance-id>",
+		Short: "Take a snapshot of a database instance",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			snapshot, err := unifiedManager.SnapshotInstance(ctx, args[0], name)
+			if err != nil {
+				return fmt.Errorf("failed to create snapshot: %w", err)
+			}
+
+			fmt.Printf("Snapshot %s of instance %s created (%d bytes).\n", snapshot.ID, snapshot.InstanceID, snapshot.Size)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Optional label for the snapshot")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// newSnapshotListCommand creates the database snapshot list command.
+func newSnapshotListCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runSnapshotList(format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+
+	return cmd
+}
+
+// newSnapshotRestoreCommand creates the database snapshot restore command.
+func newSnapshotRestoreCommand() *cobra.Command {
+	var target string
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "restore <snapshot-id>",
+		Short: "Restore a snapshot into a new or existing instance",
+		Long: `Restore a snapshot into a new or existing database instance.
+
+Without --into, a new instance with the snapshot's type, version, database and
+username is created. With --into, the snapshot is applied to that instance and
+replaces the objects it contains.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			instance, err := unifiedManager.RestoreSnapshot(ctx, args[0], target)
+			if err != nil {
+				return fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+
+			output, err := json.MarshalIndent(instance, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal instance to JSON: %w", err)
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&target, "into", "", "Instance to restore into (default: create a new instance)")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// newSnapshotDeleteCommand creates the database snapshot delete command.
+func newSnapshotDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <snapshot-id>",
+		Short: "Delete a stored snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			snapshots, err := store.NewDefaultSnapshotStore()
+			if err != nil {
+				return fmt.Errorf("failed to open snapshot store: %w", err)
+			}
+
+			snapshot, err := snapshots.Delete(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to delete snapshot: %w", err)
+			}
+
+			fmt.Printf("Snapshot %s has been successfully deleted.\n", snapshot.ID)
+			return nil
+		},
+	}
+}
+
+// runSnapshotList lists all stored snapshots.
+func runSnapshotList(format string) error {
+	snapshotStore, err := store.NewDefaultSnapshotStore()
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot store: %w", err)
+	}
+
+	snapshots, err := snapshotStore.List()
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	switch format {
+	case "json":
+		if snapshots == nil {
+			snapshots = []*types.Snapshot{}
+		}
+		response := map[string]any{
+			"count":     len(snapshots),
+			"snapshots": snapshots,
+		}
+		output, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshots to JSON: %w", err)
+		}
+		fmt.Println(string(output))
+	case "table":
+		if len(snapshots) == 0 {
+			fmt.Println("No snapshots exist.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SNAPSHOT ID\tNAME\tINSTANCE ID\tTYPE\tVERSION\tDATABASE\tSIZE\tCREATED")
+		for _, snapshot := range snapshots {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+				shortID(snapshot.ID),
+				snapshot.Name,
+				shortID(snapshot.InstanceID),
+				snapshot.Type,
+				snapshot.Version,
+				snapshot.Database,
+				snapshot.Size,
+				snapshot.CreatedAt.Format(time.RFC3339))
+		}
+		w.Flush()
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return nil
+}
+
+// shortID truncates an ID to the 12 characters shown in tables.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
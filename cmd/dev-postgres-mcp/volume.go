@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// newDatabaseVolumeCommand creates the database volume command group.
+func newDatabaseVolumeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Manage data volumes of persistent instances",
+		Long: `Manage the named volumes that hold the data of persistent instances.
+
+A persistent instance stores its data in a managed volume instead of the
+container's writable layer. Detaching removes the container but keeps the
+volume; attaching recreates the instance (same ID, new port) from it.`,
+	}
+
+	cmd.AddCommand(newVolumeListCommand())
+	cmd.AddCommand(newVolumeDetachCommand())
+	cmd.AddCommand(newVolumeAttachCommand())
+	cmd.AddCommand(newVolumeDeleteCommand())
+
+	return cmd
+}
+
+// newVolumeListCommand creates the database volume list command.
+func newVolumeListCommand() *cobra.Command {
+	var format string
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List data volumes, including detached ones",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runVolumeList(format, startPort, endPort)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// newVolumeDetachCommand creates the database volume detach command.
+func newVolumeDetachCommand() *cobra.Command {
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "detach <instance-id>",
+		Short: "Remove a persistent instance's container but keep its data volume",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			info, err := unifiedManager.DetachInstance(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to detach instance: %w", err)
+			}
+
+			fmt.Printf("Database instance %s detached; data kept in volume %s.\n", info.InstanceID, info.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// newVolumeAttachCommand creates the database volume attach command.
+func newVolumeAttachCommand() *cobra.Command {
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "attach <volume>",
+		Short: "Recreate an instance from a detached data volume",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			instance, err := unifiedManager.AttachVolume(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to attach volume: %w", err)
+			}
+
+			output, err := json.MarshalIndent(instance, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal instance to JSON: %w", err)
+			}
+
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// newVolumeDeleteCommand creates the database volume delete command.
+func newVolumeDeleteCommand() *cobra.Command {
+	var startPort int
+	var endPort int
+
+	cmd := &cobra.Command{
+		Use:   "delete <volume>",
+		Short: "Delete a detached data volume and all its data",
+		Long: `Delete a detached data volume and all its data.
+
+WARNING: This action is irreversible. Volumes that are attached to an instance
+cannot be deleted; detach or drop the instance first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			if err := unifiedManager.DeleteVolume(ctx, args[0]); err != nil {
+				return fmt.Errorf("failed to delete volume: %w", err)
+			}
+
+			fmt.Printf("Database volume %s has been successfully deleted.\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&startPort, "start-port", 15432, "Start of port range for database instances")
+	cmd.Flags().IntVar(&endPort, "end-port", 25432, "End of port range for database instances")
+
+	return cmd
+}
+
+// runVolumeList lists all data volumes.
+func runVolumeList(format string, startPort, endPort int) error {
+	ctx, unifiedManager, cleanup, err := connectUnifiedManager(startPort, endPort)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	volumes, err := unifiedManager.ListVolumes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	switch format {
+	case "json":
+		if volumes == nil {
+			volumes = []*types.VolumeInfo{}
+		}
+		response := map[string]any{
+			"count":   len(volumes),
+			"volumes": volumes,
+		}
+		output, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal volumes to JSON: %w", err)
+		}
+		fmt.Println(string(output))
+	case "table":
+		if len(volumes) == 0 {
+			fmt.Println("No database volumes exist.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VOLUME\tINSTANCE ID\tTYPE\tVERSION\tDATABASE\tATTACHED\tCREATED")
+		for _, volume := range volumes {
+			instanceID := volume.InstanceID
+			if len(instanceID) > 12 {
+				instanceID = instanceID[:12]
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n",
+				volume.Name,
+				instanceID,
+				volume.Type,
+				volume.Version,
+				volume.Database,
+				volume.IsAttached(),
+				volume.CreatedAt.Format(time.RFC3339))
+		}
+		w.Flush()
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// CloneInstance creates a new, independent instance with the same type, version, database,
+// username and resource limits as the source, and copies the source's data into it by piping a logical dump of the
+// source straight into the new instance. The clone gets its own port and password.
+func (m *UnifiedManager) CloneInstance(ctx context.Context, sourceID string, opts types.CloneInstanceOptions) (*types.DatabaseInstance, error) {
+	sourceManager, source, err := m.instanceManager(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fail before creating the clone if the engine cannot copy its data
+	if engine, ok := types.LookupEngine(source.Type); !ok || !engine.Capabilities().Snapshots {
+		return nil, fmt.Errorf("%s instances do not support cloning", source.Type)
+	}
+
+	slog.Info("Cloning database instance", "source_id", source.ID, "type", source.Type, "version", source.Version)
+
+	createOpts := types.CreateInstanceOptions{
+		Type:       source.Type,
+		Version:    source.Version,
+		Database:   source.Database,
+		Username:   source.Username,
+		TTL:        opts.TTL,
+		Persistent: opts.Persistent,
+	}
+	if source.Resources != nil {
+		// The clone gets the source's resource limits
+		createOpts.Memory = source.Resources.Memory
+		createOpts.CPUs = source.Resources.CPUs
+		createOpts.ShmSize = source.Resources.ShmSize
+		createOpts.Tmpfs = source.Resources.Tmpfs && !opts.Persistent
+	}
+
+	clone, err := m.CreateInstance(ctx, createOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clone of instance %s: %w", source.ID, err)
+	}
+
+	if err := copyDatabase(ctx, sourceManager, source.ID, clone.ID); err != nil {
+		if dropErr := m.DropInstance(ctx, clone.ID); dropErr != nil {
+			slog.Warn("Failed to remove clone after failed copy", "instance_id", clone.ID, "error", dropErr)
+		}
+		return nil, fmt.Errorf("failed to copy data from instance %s: %w", source.ID, err)
+	}
+
+	slog.Info("Database instance cloned", "source_id", source.ID, "instance_id", clone.ID, "port", clone.Port)
+
+	return clone, nil
+}
+
+// copyDatabase streams a dump of the source instance into the target instance. Both instances
+// must be managed by the given manager.
+func copyDatabase(ctx context.Context, manager types.DatabaseManager, sourceID, targetID string) error {
+	reader, writer := io.Pipe()
+
+	dumpErr := make(chan error, 1)
+	go func() {
+		err := manager.DumpDatabase(ctx, sourceID, writer)
+		writer.CloseWithError(err)
+		dumpErr <- err
+	}()
+
+	restoreErr := manager.RestoreDatabase(ctx, targetID, reader)
+	// Unblock the dump if the restore stopped reading early
+	reader.CloseWithError(fmt.Errorf("restore finished"))
+
+	if err := <-dumpErr; err != nil {
+		return err
+	}
+	return restoreErr
+}
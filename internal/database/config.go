@@ -0,0 +1,56 @@
+package database
+
+import (
+	"log/slog"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// ManagerConfig holds configuration shared by the database managers.
+type ManagerConfig struct {
+	// Credentials persists instance passwords across processes. If nil, passwords are
+	// only known to the process that created the instance.
+	Credentials types.CredentialStore
+
+	// OwnerID is recorded on every instance created by the manager. Instances created by
+	// other owners are never removed by Cleanup under CleanupPolicyOwned.
+	OwnerID string
+
+	// CleanupPolicy determines which instances Cleanup removes (defaults to CleanupPolicyOwned).
+	CleanupPolicy types.CleanupPolicy
+
+	// Snapshots stores instance snapshots. If nil, snapshots are not available.
+	Snapshots *store.SnapshotStore
+
+	// Resources holds the resource defaults and maximums applied to new instances.
+	Resources types.ResourcePolicy
+
+	// Quotas limits the instances the unified manager creates.
+	Quotas types.QuotaPolicy
+
+	// InitScripts restricts the init script paths and URLs that remote clients may use.
+	InitScripts types.InitScriptPolicy
+}
+
+// DefaultManagerConfig returns the configuration used by NewUnifiedManager.
+// Instance passwords and snapshots are persisted in the default storage directory.
+func DefaultManagerConfig() ManagerConfig {
+	var config ManagerConfig
+
+	credentials, err := store.NewDefaultCredentialStore()
+	if err != nil {
+		slog.Warn("Credential store is not available, passwords will not be persisted", "error", err)
+	} else {
+		config.Credentials = credentials
+	}
+
+	snapshots, err := store.NewDefaultSnapshotStore()
+	if err != nil {
+		slog.Warn("Snapshot store is not available, snapshots are disabled", "error", err)
+	} else {
+		config.Snapshots = snapshots
+	}
+
+	return config
+}
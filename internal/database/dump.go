@@ -0,0 +1,179 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"text/template"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// maxStderrBytes limits how much of a client tool's standard error is kept for error messages.
+const maxStderrBytes = 4096
+
+// DumpDatabase writes a logical SQL dump of an instance's database to w, using the
+// engine's dump tool inside the container.
+func (m *GenericManager) DumpDatabase(ctx context.Context, id string, w io.Writer) error {
+	if len(m.config.dumpTemplates) == 0 {
+		return fmt.Errorf("%s instances do not support dumps", m.config.Type)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Dumping database", "type", m.config.Type, "instance_id", instance.ID, "database", instance.Database)
+
+	return m.runClientTool(ctx, instance, "dump", m.config.dumpTemplates, nil, w)
+}
+
+// RestoreDatabase applies SQL read from r to an instance's database, using the engine's
+// client inside the container. Execution stops at the first failing statement where the
+// client supports it.
+func (m *GenericManager) RestoreDatabase(ctx context.Context, id string, r io.Reader) error {
+	if len(m.config.restoreTemplates) == 0 {
+		return fmt.Errorf("%s instances do not support restores", m.config.Type)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Restoring database", "type", m.config.Type, "instance_id", instance.ID, "database", instance.Database)
+
+	return m.runClientTool(ctx, instance, "restore", m.config.restoreTemplates, r, nil)
+}
+
+// runClientTool runs one of the engine's client tools inside an instance's container.
+func (m *GenericManager) runClientTool(ctx context.Context, instance *types.DatabaseInstance, action string,
+	command []*template.Template, stdin io.Reader, stdout io.Writer) error {
+	if instance.Password == "" {
+		return fmt.Errorf("password for %s instance %s is not known", m.config.Type, instance.ID)
+	}
+
+	data := TemplateData{
+		Database: instance.Database,
+		Username: instance.Username,
+		Password: instance.Password,
+	}
+
+	cmd, err := m.renderCommand(command, data)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s command template: %w", action, err)
+	}
+
+	stderr := &limitedBuffer{limit: maxStderrBytes}
+	exitCode, err := m.execInContainer(ctx, instance, types.ExecOptions{
+		Cmd:    cmd,
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("%s of %s instance %s failed: %w", action, m.config.Type, instance.ID, err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("%s of %s instance %s failed with exit code %d: %s",
+			action, m.config.Type, instance.ID, exitCode, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// ExecInInstance runs a command inside an instance's container and returns its exit code.
+// The engine's client credentials (e.g. PGPASSWORD, MYSQL_PWD) are added to the environment,
+// so client tools connect without a password on the command line.
+func (m *GenericManager) ExecInInstance(ctx context.Context, id string, opts types.ExecOptions) (int, error) {
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return -1, err
+	}
+
+	return m.execInContainer(ctx, instance, opts)
+}
+
+// execInContainer runs a command inside an instance's container with the client credentials added.
+// It does not take the manager lock, so it can be used while an instance is being created.
+func (m *GenericManager) execInContainer(ctx context.Context, instance *types.DatabaseInstance, opts types.ExecOptions) (int, error) {
+	if len(opts.Cmd) == 0 {
+		return -1, fmt.Errorf("command must not be empty")
+	}
+
+	data := TemplateData{
+		Database: instance.Database,
+		Username: instance.Username,
+		Password: instance.Password,
+	}
+
+	env, err := m.clientEnvironment(data)
+	if err != nil {
+		return -1, err
+	}
+	// Explicit variables come last so they take precedence
+	opts.Env = append(env, opts.Env...)
+
+	slog.Debug("Executing command in container", "type", m.config.Type, "instance_id", instance.ID, "command", opts.Cmd[0])
+
+	return m.docker.Exec(ctx, instance.ContainerID, opts)
+}
+
+// renderCommand executes a list of command templates.
+func (m *GenericManager) renderCommand(command []*template.Template, data TemplateData) ([]string, error) {
+	cmd := make([]string, 0, len(command))
+	for _, tmpl := range command {
+		part, err := m.executeTemplate(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		cmd = append(cmd, part)
+	}
+	return cmd, nil
+}
+
+// clientEnvironment returns the environment variables that let the engine's client tools
+// authenticate. It is empty if the password is not known.
+func (m *GenericManager) clientEnvironment(data TemplateData) ([]string, error) {
+	if data.Password == "" {
+		return nil, nil
+	}
+
+	env := make([]string, 0, len(m.config.clientTemplates))
+	for key, tmpl := range m.config.clientTemplates {
+		value, err := m.executeTemplate(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute client environment template for %s: %w", key, err)
+		}
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	return env, nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if len(p) > remaining {
+		b.truncated = true
+		if remaining > 0 {
+			b.buf.Write(p[:remaining])
+		}
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// Subscribe registers a function that is called with every change to the managed instances:
+// instances created, dropped, stopped, started or restarted through the manager, and changes
+// found when the instances are listed, such as instances whose health changed or that were
+// created or removed by another process. The function is called synchronously and must not
+// block or call back into the manager.
+func (m *UnifiedManager) Subscribe(fn func(types.InstanceEvent)) {
+	m.listenersMu.Lock()
+	defer m.listenersMu.Unlock()
+	m.listeners = append(m.listeners, fn)
+}
+
+// publish calls the subscribed functions with an event.
+func (m *UnifiedManager) publish(event types.InstanceEvent) {
+	m.listenersMu.Lock()
+	listeners := m.listeners
+	m.listenersMu.Unlock()
+
+	for _, fn := range listeners {
+		fn(event)
+	}
+}
+
+// setStatus records an instance's new status in the registry and publishes the change.
+func (m *UnifiedManager) setStatus(instance *types.DatabaseInstance, status string) {
+	updated := *instance
+	updated.Status = status
+
+	m.mu.Lock()
+	m.instances[instance.ID] = &updated
+	m.mu.Unlock()
+
+	m.publish(types.InstanceEvent{Type: types.InstanceEventStatusChanged, InstanceID: instance.ID, Status: status})
+}
+
+// publishChanges publishes the differences between the registry before and after listing the
+// instances.
+func (m *UnifiedManager) publishChanges(previous map[string]*types.DatabaseInstance, current []*types.DatabaseInstance) {
+	seen := make(map[string]bool, len(current))
+	for _, instance := range current {
+		seen[instance.ID] = true
+		before, known := previous[instance.ID]
+		switch {
+		case !known:
+			m.publish(types.InstanceEvent{Type: types.InstanceEventCreated, InstanceID: instance.ID, Status: instance.Status})
+		case before.Status != instance.Status:
+			m.publish(types.InstanceEvent{Type: types.InstanceEventStatusChanged, InstanceID: instance.ID, Status: instance.Status})
+		}
+	}
+
+	for id := range previous {
+		if !seen[id] {
+			m.publish(types.InstanceEvent{Type: types.InstanceEventDropped, InstanceID: id})
+		}
+	}
+}
+
+// CheckForChanges compares the managed containers with the registry and publishes the changes
+// found: instances whose status changed, that were removed, or that were created by another
+// process. Unlike ListInstances it only lists the containers, so it neither looks up stored
+// credentials nor waits for instances that are being created.
+func (m *UnifiedManager) CheckForChanges(ctx context.Context) error {
+	current := make(map[string]container.Summary)
+	for dbType := range m.managers {
+		containers, err := m.docker.ListContainersByType(ctx, dbType)
+		if err != nil {
+			return fmt.Errorf("failed to list %s containers: %w", dbType, err)
+		}
+		for _, cont := range containers {
+			if id := cont.Labels["dev-postgres-mcp.instance-id"]; id != "" {
+				current[id] = cont
+			}
+		}
+	}
+
+	var events []types.InstanceEvent
+	m.mu.Lock()
+	for id, cont := range current {
+		status := containerStatus(cont)
+		instance, known := m.instances[id]
+		switch {
+		case !known:
+			// Instances created by this manager are published once they have been created
+			if m.ownerID != "" && cont.Labels["dev-postgres-mcp.owner"] == m.ownerID {
+				continue
+			}
+			events = append(events, types.InstanceEvent{Type: types.InstanceEventCreated, InstanceID: id, Status: status})
+		case instance.Status != status:
+			updated := *instance
+			updated.Status = status
+			m.instances[id] = &updated
+			events = append(events, types.InstanceEvent{Type: types.InstanceEventStatusChanged, InstanceID: id, Status: status})
+		}
+	}
+	for id := range m.instances {
+		if _, exists := current[id]; !exists {
+			delete(m.instances, id)
+			events = append(events, types.InstanceEvent{Type: types.InstanceEventDropped, InstanceID: id})
+		}
+	}
+	m.mu.Unlock()
+
+	for _, event := range events {
+		m.publish(event)
+	}
+	return nil
+}
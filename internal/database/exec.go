@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// ExecInInstance runs a command inside an instance's container, streaming its input and
+// output through opts, and returns its exit code.
+func (m *UnifiedManager) ExecInInstance(ctx context.Context, id string, opts types.ExecOptions) (int, error) {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return -1, err
+	}
+
+	return manager.ExecInInstance(ctx, instance.ID, opts)
+}
+
+// RunInInstance runs a command inside an instance's container and captures up to maxOutputBytes
+// of its standard output and standard error each. Zero or out-of-range limits are replaced by
+// types.DefaultExecTimeout and types.DefaultExecMaxOutputBytes, or clamped to their maxima.
+// Stdout and Stderr in opts are ignored.
+func (m *UnifiedManager) RunInInstance(ctx context.Context, id string, opts types.ExecOptions, maxOutputBytes int) (*types.ExecResult, error) {
+	if len(opts.Cmd) == 0 || strings.TrimSpace(opts.Cmd[0]) == "" {
+		return nil, fmt.Errorf("command must not be empty")
+	}
+
+	switch {
+	case opts.Timeout <= 0:
+		opts.Timeout = types.DefaultExecTimeout
+	case opts.Timeout > types.MaxExecTimeout:
+		opts.Timeout = types.MaxExecTimeout
+	}
+	switch {
+	case maxOutputBytes <= 0:
+		maxOutputBytes = types.DefaultExecMaxOutputBytes
+	case maxOutputBytes > types.MaxExecMaxOutputBytes:
+		maxOutputBytes = types.MaxExecMaxOutputBytes
+	}
+
+	stdout := &limitedBuffer{limit: maxOutputBytes}
+	stderr := &limitedBuffer{limit: maxOutputBytes}
+	opts.Stdout = stdout
+	opts.Stderr = stderr
+
+	slog.Info("Running command in instance", "instance_id", id, "command", opts.Cmd[0], "timeout", opts.Timeout)
+
+	start := time.Now()
+	exitCode, err := m.ExecInInstance(ctx, id, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ExecResult{
+		ExitCode:        exitCode,
+		Stdout:          stdout.String(),
+		Stderr:          stderr.String(),
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
+		Duration:        time.Since(start).String(),
+	}, nil
+}
@@ -26,110 +26,154 @@ type DatabaseConfig struct {
 	DefaultDatabase     string
 	DefaultUsername     string
 	EnvironmentTemplate map[string]string // Template strings for environment variables
+	ContainerCommand    []string          // Command template strings overriding the image's default command
 	HealthCheckCommand  []string          // Health check command template strings
 	ContainerPort       string            // Internal container port
+	DataDir             string            // Data directory inside the container, used for persistent volumes and tmpfs mounts
+	MemoryLimit         int64             // Default container memory limit in bytes (zero uses the server default)
+	SetupCommand        []string          // Command template strings run once the instance is healthy
+	DriverName          string            // database/sql driver used to connect to the instance
+	ConnectionsQuery    string            // Query returning the number of other client connections
+	VersionQuery        string            // Query returning the server version
+	UptimeQuery         string            // Query returning the server uptime in seconds (in its last column)
+	ColumnsQuery        string            // Query returning the table, name, type and nullability of the database's columns
+	ClientEnvironment   map[string]string // Environment template strings for client tools run inside the container
+	DumpCommand         []string          // Command template strings writing a logical dump of the database to stdout
+	RestoreCommand      []string          // Command template strings applying SQL read from stdin to the database
+	ShellCommand        []string          // Command template strings for an interactive client inside the container
+	HostShellCommand    []string          // Command template strings for an interactive client on the host
 
 	// Compiled templates (populated during initialization)
-	envTemplates    map[string]*template.Template
-	healthTemplates []*template.Template
+	envTemplates     map[string]*template.Template
+	commandTemplates []*template.Template
+	healthTemplates  []*template.Template
+	clientTemplates  map[string]*template.Template
+	dumpTemplates    []*template.Template
+	restoreTemplates []*template.Template
+	setupTemplates   []*template.Template
+	shellTemplates   []*template.Template
+	hostTemplates    []*template.Template
 }
 
-// GetDatabaseConfig returns configuration for a specific database type.
+// GetDatabaseConfig returns configuration for a registered database type.
 func GetDatabaseConfig(dbType types.DatabaseType) DatabaseConfig {
-	var config DatabaseConfig
-
-	switch dbType {
-	case types.DatabaseTypePostgreSQL:
-		config = DatabaseConfig{
-			Type:            types.DatabaseTypePostgreSQL,
-			DefaultPort:     5432,
-			DefaultVersion:  "17",
-			DefaultDatabase: "postgres",
-			DefaultUsername: "postgres",
-			EnvironmentTemplate: map[string]string{
-				"POSTGRES_DB":       "{{.Database}}",
-				"POSTGRES_USER":     "{{.Username}}",
-				"POSTGRES_PASSWORD": "{{.Password}}",
-			},
-			HealthCheckCommand: []string{"CMD-SHELL", "pg_isready -U {{.Username}} -d {{.Database}}"},
-			ContainerPort:      "5432/tcp",
-		}
-	case types.DatabaseTypeMySQL:
-		config = DatabaseConfig{
-			Type:            types.DatabaseTypeMySQL,
-			DefaultPort:     3306,
-			DefaultVersion:  "8.0",
-			DefaultDatabase: "mysql",
-			DefaultUsername: "root",
-			EnvironmentTemplate: map[string]string{
-				"MYSQL_DATABASE":      "{{.Database}}",
-				"MYSQL_ROOT_PASSWORD": "{{.Password}}",
-			},
-			HealthCheckCommand: []string{"CMD-SHELL", "mysqladmin ping -u root -p{{.Password}} --silent"},
-			ContainerPort:      "3306/tcp",
-		}
-	case types.DatabaseTypeMariaDB:
-		config = DatabaseConfig{
-			Type:            types.DatabaseTypeMariaDB,
-			DefaultPort:     3306,
-			DefaultVersion:  "11",
-			DefaultDatabase: "mysql",
-			DefaultUsername: "root",
-			EnvironmentTemplate: map[string]string{
-				"MARIADB_DATABASE":      "{{.Database}}",
-				"MARIADB_ROOT_PASSWORD": "{{.Password}}",
-			},
-			HealthCheckCommand: []string{"CMD-SHELL", "mariadb -u root -p{{.Password}} -e 'SELECT 1' || mysqladmin ping -h localhost -u root -p{{.Password}}"},
-			ContainerPort:      "3306/tcp",
-		}
-	default:
+	engine, ok := types.LookupEngine(dbType)
+	if !ok {
 		panic(fmt.Sprintf("unsupported database type: %s", dbType))
 	}
 
+	config := DatabaseConfig{
+		Type:                engine.Type,
+		DefaultPort:         engine.Port,
+		DefaultVersion:      engine.DefaultVersion,
+		DefaultDatabase:     engine.DefaultDatabase,
+		DefaultUsername:     engine.DefaultUsername,
+		EnvironmentTemplate: engine.Environment,
+		ContainerCommand:    engine.Command,
+		HealthCheckCommand:  engine.HealthCheck,
+		ContainerPort:       fmt.Sprintf("%d/tcp", engine.Port),
+		DataDir:             engine.DataDir,
+		MemoryLimit:         engine.MemoryLimit,
+		SetupCommand:        engine.SetupCommand,
+		DriverName:          engine.DriverName,
+		ConnectionsQuery:    engine.ConnectionsQuery,
+		VersionQuery:        engine.VersionQuery,
+		UptimeQuery:         engine.UptimeQuery,
+		ColumnsQuery:        engine.ColumnsQuery,
+		ClientEnvironment:   engine.ClientEnvironment,
+		DumpCommand:         engine.DumpCommand,
+		RestoreCommand:      engine.RestoreCommand,
+		ShellCommand:        engine.ShellCommand,
+		HostShellCommand:    engine.HostShellCommand,
+	}
+
 	// Compile templates
-	config.envTemplates = make(map[string]*template.Template)
-	for key, tmplStr := range config.EnvironmentTemplate {
+	config.envTemplates = compileTemplateMap(dbType, "environment", config.EnvironmentTemplate)
+	config.commandTemplates = compileTemplateList(dbType, "container command", config.ContainerCommand)
+	config.healthTemplates = compileTemplateList(dbType, "health check", config.HealthCheckCommand)
+	config.clientTemplates = compileTemplateMap(dbType, "client environment", config.ClientEnvironment)
+	config.dumpTemplates = compileTemplateList(dbType, "dump command", config.DumpCommand)
+	config.restoreTemplates = compileTemplateList(dbType, "restore command", config.RestoreCommand)
+	config.setupTemplates = compileTemplateList(dbType, "setup command", config.SetupCommand)
+	config.shellTemplates = compileTemplateList(dbType, "shell command", config.ShellCommand)
+	config.hostTemplates = compileTemplateList(dbType, "host shell command", config.HostShellCommand)
+
+	return config
+}
+
+// compileTemplateMap compiles a map of template strings, panicking on invalid templates.
+func compileTemplateMap(dbType types.DatabaseType, kind string, templates map[string]string) map[string]*template.Template {
+	compiled := make(map[string]*template.Template, len(templates))
+	for key, tmplStr := range templates {
 		tmpl, err := template.New(key).Parse(tmplStr)
 		if err != nil {
-			panic(fmt.Sprintf("failed to parse environment template for %s.%s: %v", dbType, key, err))
+			panic(fmt.Sprintf("failed to parse %s template for %s.%s: %v", kind, dbType, key, err))
 		}
-		config.envTemplates[key] = tmpl
+		compiled[key] = tmpl
 	}
+	return compiled
+}
 
-	config.healthTemplates = make([]*template.Template, len(config.HealthCheckCommand))
-	for i, tmplStr := range config.HealthCheckCommand {
-		tmpl, err := template.New(fmt.Sprintf("health_%d", i)).Parse(tmplStr)
+// compileTemplateList compiles a list of template strings, panicking on invalid templates.
+func compileTemplateList(dbType types.DatabaseType, kind string, templates []string) []*template.Template {
+	compiled := make([]*template.Template, len(templates))
+	for i, tmplStr := range templates {
+		tmpl, err := template.New(fmt.Sprintf("%s_%d", kind, i)).Parse(tmplStr)
 		if err != nil {
-			panic(fmt.Sprintf("failed to parse health check template for %s[%d]: %v", dbType, i, err))
+			panic(fmt.Sprintf("failed to parse %s template for %s[%d]: %v", kind, dbType, i, err))
 		}
-		config.healthTemplates[i] = tmpl
+		compiled[i] = tmpl
 	}
-
-	return config
+	return compiled
 }
 
 // GenericManager implements DatabaseManager for any database type using configuration.
 type GenericManager struct {
-	mu        sync.RWMutex
-	instances map[string]*types.DatabaseInstance
-	docker    *docker.Manager
-	config    DatabaseConfig
+	mu          sync.RWMutex
+	instances   map[string]*types.DatabaseInstance
+	docker      *docker.Manager
+	config      DatabaseConfig
+	credentials types.CredentialStore
+	ownerID     string
+	cleanup     types.CleanupPolicy
+	resources   types.ResourcePolicy
+	initScripts types.InitScriptPolicy
+
+	// listedPorts are the ports reserved because a listing saw a container bound to them.
+	// They are released once a later listing no longer sees the container, which covers
+	// containers dropped by other processes.
+	portsMu     sync.Mutex
+	listedPorts map[int]bool
 }
 
 // NewGenericManager creates a new generic database manager for the specified type.
 func NewGenericManager(dockerManager *docker.Manager, dbType types.DatabaseType) *GenericManager {
+	return NewGenericManagerWithConfig(dockerManager, dbType, ManagerConfig{})
+}
+
+// NewGenericManagerWithConfig creates a new generic database manager for the specified type
+// using the given manager configuration.
+func NewGenericManagerWithConfig(dockerManager *docker.Manager, dbType types.DatabaseType, config ManagerConfig) *GenericManager {
+	cleanup := config.CleanupPolicy
+	if cleanup == "" {
+		cleanup = types.CleanupPolicyOwned
+	}
+
 	return &GenericManager{
-		instances: make(map[string]*types.DatabaseInstance),
-		docker:    dockerManager,
-		config:    GetDatabaseConfig(dbType),
+		instances:   make(map[string]*types.DatabaseInstance),
+		docker:      dockerManager,
+		config:      GetDatabaseConfig(dbType),
+		credentials: config.Credentials,
+		ownerID:     config.OwnerID,
+		cleanup:     cleanup,
+		resources:   config.Resources,
+		initScripts: config.InitScripts,
+		listedPorts: make(map[int]bool),
 	}
 }
 
 // CreateInstance creates a new database instance.
 func (m *GenericManager) CreateInstance(ctx context.Context, opts types.CreateInstanceOptions) (*types.DatabaseInstance, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	// Generate instance ID
 	instanceID := types.GenerateInstanceID()
 
@@ -146,16 +190,37 @@ func (m *GenericManager) CreateInstance(ctx context.Context, opts types.CreateIn
 		return nil, fmt.Errorf("failed to allocate port: %w", err)
 	}
 
+	// Create the data volume for persistent instances
+	var volumeName string
+	if opts.Persistent {
+		volumeName, err = m.createVolume(ctx, instanceID, opts)
+		if err != nil {
+			m.docker.ReleasePort(port)
+			return nil, err
+		}
+	}
+
 	// Create and start container
-	instance, err := m.createContainer(ctx, instanceID, opts, port)
+	instance, err := m.createContainer(ctx, instanceID, opts, port, volumeName)
 	if err != nil {
-		// Release port on failure
+		// Release port and volume on failure, even if the request was cancelled
 		m.docker.ReleasePort(port)
+		if volumeName != "" {
+			if removeErr := m.docker.RemoveVolume(context.WithoutCancel(ctx), volumeName); removeErr != nil {
+				slog.Warn("Failed to remove volume", "type", m.config.Type, "volume", volumeName, "error", removeErr)
+			}
+		}
 		return nil, fmt.Errorf("failed to create %s container: %w", m.config.Type, err)
 	}
 
-	// Store instance
+	// Store instance. The lock is not held while the container starts, so that listings
+	// and other instances are not held up by the health wait.
+	m.mu.Lock()
 	m.instances[instanceID] = instance
+	m.mu.Unlock()
+
+	// Persist the password so other processes can build a complete DSN
+	m.savePassword(instanceID, instance.Password)
 
 	slog.Info("Database instance created successfully",
 		"type", m.config.Type,
@@ -175,6 +240,7 @@ func (m *GenericManager) ListInstances(ctx context.Context) ([]*types.DatabaseIn
 	}
 
 	var instances []*types.DatabaseInstance
+	seenPorts := make(map[int]bool)
 	for _, cont := range containers {
 		// Extract instance information from container labels
 		instanceID := cont.Labels["dev-postgres-mcp.instance-id"]
@@ -187,18 +253,19 @@ func (m *GenericManager) ListInstances(ctx context.Context) ([]*types.DatabaseIn
 		version := cont.Labels["dev-postgres-mcp.version"]
 		portStr := cont.Labels["dev-postgres-mcp.port"]
 		createdAtStr := cont.Labels["dev-postgres-mcp.created-at"]
+		owner := cont.Labels["dev-postgres-mcp.owner"]
+		session := cont.Labels["dev-postgres-mcp.session"]
+		principal := cont.Labels["dev-postgres-mcp.principal"]
+		expiresAtStr := cont.Labels["dev-postgres-mcp.expires-at"]
+		volumeName := cont.Labels["dev-postgres-mcp.volume"]
+		memoryStr := cont.Labels["dev-postgres-mcp.memory"]
 
 		port, _ := strconv.Atoi(portStr)
 		createdAt, _ := time.Parse(time.RFC3339, createdAtStr)
 
-		// Determine status
-		status := "unknown"
-		if len(cont.Names) > 0 {
-			if cont.State == "running" {
-				status = "running"
-			} else {
-				status = cont.State
-			}
+		// The container keeps its port binding even while stopped, so keep it reserved
+		if port > 0 {
+			seenPorts[port] = true
 		}
 
 		instance := &types.DatabaseInstance{
@@ -208,18 +275,38 @@ func (m *GenericManager) ListInstances(ctx context.Context) ([]*types.DatabaseIn
 			Port:        port,
 			Database:    database,
 			Username:    username,
+			Password:    m.lookupPassword(instanceID),
 			Version:     version,
 			CreatedAt:   createdAt,
-			Status:      status,
+			Status:      containerStatus(cont),
+			Owner:       owner,
+			Session:     session,
+			Principal:   principal,
+			Volume:      volumeName,
+		}
+		if expiresAt, err := time.Parse(time.RFC3339, expiresAtStr); err == nil {
+			instance.ExpiresAt = &expiresAt
+		}
+		if memory, err := strconv.ParseInt(memoryStr, 10, 64); err == nil {
+			cpus, _ := strconv.ParseFloat(cont.Labels["dev-postgres-mcp.cpus"], 64)
+			shmSize, _ := strconv.ParseInt(cont.Labels["dev-postgres-mcp.shm-size"], 10, 64)
+			instance.Resources = &types.ResourceLimits{
+				Memory:  memory,
+				CPUs:    cpus,
+				ShmSize: shmSize,
+				Tmpfs:   cont.Labels["dev-postgres-mcp.tmpfs"] == "true",
+			}
 		}
 
-		// We don't store password in labels for security, so we can't retrieve it
-		// The DSN will be incomplete, but that's acceptable for listing
+		// Passwords are not stored in labels for security; they come from the credential store
+		// or from this process's own records. Without either, the DSN will be incomplete.
 		instance.DSN = types.BuildDSN(instance)
 
 		instances = append(instances, instance)
 	}
 
+	m.syncListedPorts(seenPorts)
+
 	// Update in-memory instances
 	m.mu.Lock()
 	m.instances = make(map[string]*types.DatabaseInstance)
@@ -231,6 +318,34 @@ func (m *GenericManager) ListInstances(ctx context.Context) ([]*types.DatabaseIn
 	return instances, nil
 }
 
+// syncListedPorts reserves the ports of listed containers and releases ports reserved by
+// earlier listings whose containers are gone.
+func (m *GenericManager) syncListedPorts(seen map[int]bool) {
+	m.portsMu.Lock()
+	defer m.portsMu.Unlock()
+
+	for port := range m.listedPorts {
+		if !seen[port] {
+			m.docker.ReleasePort(port)
+			delete(m.listedPorts, port)
+		}
+	}
+	for port := range seen {
+		m.docker.ReservePort(port)
+		m.listedPorts[port] = true
+	}
+}
+
+// releasePort releases a port whose container this process removed, so a later listing
+// does not release it again after it has been handed to a new instance.
+func (m *GenericManager) releasePort(port int) {
+	m.portsMu.Lock()
+	delete(m.listedPorts, port)
+	m.portsMu.Unlock()
+
+	m.docker.ReleasePort(port)
+}
+
 // GetInstance returns a specific database instance by ID.
 func (m *GenericManager) GetInstance(ctx context.Context, id string) (*types.DatabaseInstance, error) {
 	// First try exact match in-memory instances
@@ -297,7 +412,21 @@ func (m *GenericManager) DropInstance(ctx context.Context, id string) error {
 	}
 
 	// Release port
-	m.docker.ReleasePort(instance.Port)
+	m.releasePort(instance.Port)
+
+	// Remove the data volume of persistent instances
+	if instance.Volume != "" {
+		if err := m.docker.RemoveVolume(ctx, instance.Volume); err != nil {
+			return fmt.Errorf("failed to remove %s data volume: %w", m.config.Type, err)
+		}
+	}
+
+	// Purge stored credentials
+	if m.credentials != nil {
+		if err := m.credentials.DeletePassword(instance.ID); err != nil {
+			slog.Warn("Failed to delete stored password", "type", m.config.Type, "instance_id", instance.ID, "error", err)
+		}
+	}
 
 	// Remove from in-memory instances
 	m.mu.Lock()
@@ -308,48 +437,63 @@ func (m *GenericManager) DropInstance(ctx context.Context, id string) error {
 	return nil
 }
 
-// HealthCheck performs a health check on a database instance.
-func (m *GenericManager) HealthCheck(ctx context.Context, id string) (*types.HealthCheckResult, error) {
+// StopInstance stops a database instance without removing its container or data.
+// The instance's port stays reserved so it can be started again on the same port.
+func (m *GenericManager) StopInstance(ctx context.Context, id string) error {
 	instance, err := m.GetInstance(ctx, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	start := time.Now()
-	status, err := m.getContainerStatus(ctx, instance.ContainerID)
-	duration := time.Since(start)
+	slog.Info("Stopping database instance", "type", m.config.Type, "instance_id", instance.ID)
 
-	result := &types.HealthCheckResult{
-		Duration:  duration.String(),
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	if err := m.docker.StopContainer(ctx, instance.ContainerID); err != nil {
+		return fmt.Errorf("failed to stop %s container: %w", m.config.Type, err)
 	}
 
+	slog.Info("Database instance stopped successfully", "type", m.config.Type, "instance_id", instance.ID)
+	return nil
+}
+
+// StartInstance starts a stopped database instance and waits for it to become healthy.
+func (m *GenericManager) StartInstance(ctx context.Context, id string) error {
+	instance, err := m.GetInstance(ctx, id)
 	if err != nil {
-		result.Status = types.HealthStatusUnknown
-		result.Message = fmt.Sprintf("Failed to check status: %v", err)
-		return result, nil
-	}
-
-	switch status {
-	case "running":
-		result.Status = types.HealthStatusHealthy
-		result.Message = fmt.Sprintf("%s instance is running and healthy", m.config.Type)
-	case "starting":
-		result.Status = types.HealthStatusStarting
-		result.Message = fmt.Sprintf("%s instance is starting up", m.config.Type)
-	case "unhealthy":
-		result.Status = types.HealthStatusUnhealthy
-		result.Message = fmt.Sprintf("%s instance is unhealthy", m.config.Type)
-	default:
-		result.Status = types.HealthStatusUnknown
-		result.Message = fmt.Sprintf("%s instance status: %s", m.config.Type, status)
+		return err
+	}
+
+	slog.Info("Starting database instance", "type", m.config.Type, "instance_id", instance.ID)
+
+	// Make sure no other instance is handed the port while this one starts
+	m.docker.ReservePort(instance.Port)
+
+	if err := m.docker.StartContainer(ctx, instance.ContainerID); err != nil {
+		return fmt.Errorf("failed to start %s container: %w", m.config.Type, err)
+	}
+
+	if err := m.waitForHealthy(ctx, instance.ContainerID, 120*time.Second); err != nil {
+		return fmt.Errorf("%s container: %w: %w", m.config.Type, types.ErrInstanceUnhealthy, err)
 	}
 
-	return result, nil
+	slog.Info("Database instance started successfully", "type", m.config.Type, "instance_id", instance.ID)
+	return nil
+}
+
+// RestartInstance stops and starts a database instance.
+func (m *GenericManager) RestartInstance(ctx context.Context, id string) error {
+	if err := m.StopInstance(ctx, id); err != nil {
+		return err
+	}
+	return m.StartInstance(ctx, id)
 }
 
-// Cleanup removes all database instances of this type.
+// Cleanup removes the database instances of this type selected by the cleanup policy.
 func (m *GenericManager) Cleanup(ctx context.Context) error {
+	if m.cleanup == types.CleanupPolicyNone {
+		slog.Info("Skipping cleanup", "type", m.config.Type, "policy", m.cleanup)
+		return nil
+	}
+
 	instances, err := m.ListInstances(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list %s instances for cleanup: %w", m.config.Type, err)
@@ -357,6 +501,19 @@ func (m *GenericManager) Cleanup(ctx context.Context) error {
 
 	var errors []error
 	for _, instance := range instances {
+		if !m.shouldCleanup(instance) {
+			slog.Debug("Leaving instance owned by another server", "type", m.config.Type, "instance_id", instance.ID, "owner", instance.Owner)
+			continue
+		}
+
+		// Persistent instances keep their data: detach them so they can be reattached later
+		if instance.Volume != "" {
+			if _, err := m.DetachInstance(ctx, instance.ID); err != nil {
+				errors = append(errors, err)
+			}
+			continue
+		}
+
 		if err := m.DropInstance(ctx, instance.ID); err != nil {
 			errors = append(errors, err)
 		}
@@ -369,6 +526,18 @@ func (m *GenericManager) Cleanup(ctx context.Context) error {
 	return nil
 }
 
+// shouldCleanup reports whether Cleanup should remove the instance under the configured policy.
+func (m *GenericManager) shouldCleanup(instance *types.DatabaseInstance) bool {
+	switch m.cleanup {
+	case types.CleanupPolicyAll:
+		return true
+	case types.CleanupPolicyOwned:
+		return m.ownerID != "" && instance.Owner == m.ownerID
+	default:
+		return false
+	}
+}
+
 // GetInstanceCount returns the number of database instances of this type.
 func (m *GenericManager) GetInstanceCount() int {
 	m.mu.RLock()
@@ -383,11 +552,44 @@ func (m *GenericManager) GetDatabaseType() types.DatabaseType {
 
 // Helper methods
 
+// savePassword persists an instance password in the credential store, if one is configured.
+func (m *GenericManager) savePassword(instanceID, password string) {
+	if m.credentials == nil {
+		return
+	}
+	if err := m.credentials.SavePassword(instanceID, password); err != nil {
+		slog.Warn("Failed to store password", "type", m.config.Type, "instance_id", instanceID, "error", err)
+	}
+}
+
+// lookupPassword returns the password for an instance, preferring this process's own records
+// over the credential store. It returns an empty string if the password is unknown.
+func (m *GenericManager) lookupPassword(instanceID string) string {
+	m.mu.RLock()
+	instance, exists := m.instances[instanceID]
+	m.mu.RUnlock()
+	if exists && instance.Password != "" {
+		return instance.Password
+	}
+
+	if m.credentials == nil {
+		return ""
+	}
+
+	password, err := m.credentials.LoadPassword(instanceID)
+	if err != nil {
+		slog.Warn("Failed to load stored password", "type", m.config.Type, "instance_id", instanceID, "error", err)
+		return ""
+	}
+	return password
+}
+
 // TemplateData holds the data for template execution.
 type TemplateData struct {
 	Database string
 	Username string
 	Password string
+	Port     int // Host port, only set for host-side commands
 }
 
 // executeTemplate executes a template with the given data.
@@ -400,7 +602,8 @@ func (m *GenericManager) executeTemplate(tmpl *template.Template, data TemplateD
 }
 
 // createContainer creates and starts a database container.
-func (m *GenericManager) createContainer(ctx context.Context, instanceID string, opts types.CreateInstanceOptions, port int) (*types.DatabaseInstance, error) {
+// If volumeName is not empty, the named volume is mounted at the engine's data directory.
+func (m *GenericManager) createContainer(ctx context.Context, instanceID string, opts types.CreateInstanceOptions, port int, volumeName string) (*types.DatabaseInstance, error) {
 	image := types.GetDockerImage(m.config.Type, opts.Version)
 	containerName := types.GetContainerName(instanceID, m.config.Type)
 
@@ -411,6 +614,18 @@ func (m *GenericManager) createContainer(ctx context.Context, instanceID string,
 		"port", port,
 		"database", opts.Database)
 
+	// Resolve init scripts before creating anything, so that bad paths or URLs fail fast
+	scripts, err := ResolveInitScripts(ctx, opts.InitScripts, m.initScripts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init scripts: %w", err)
+	}
+
+	// Apply the server's resource defaults and maximums
+	limits, err := m.resources.Resolve(opts, m.config.MemoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %w", err)
+	}
+
 	// Pull the image if needed
 	if err := m.docker.PullImage(ctx, image); err != nil {
 		return nil, fmt.Errorf("failed to pull %s image: %w", m.config.Type, err)
@@ -433,6 +648,16 @@ func (m *GenericManager) createContainer(ctx context.Context, instanceID string,
 		env = append(env, fmt.Sprintf("%s=%s", key, value))
 	}
 
+	// Build the container command using compiled templates
+	var command []string
+	for _, tmpl := range m.config.commandTemplates {
+		cmdPart, err := m.executeTemplate(tmpl, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute container command template: %w", err)
+		}
+		command = append(command, cmdPart)
+	}
+
 	// Build health check command using compiled templates
 	var healthCmd []string
 	for _, tmpl := range m.config.healthTemplates {
@@ -443,41 +668,93 @@ func (m *GenericManager) createContainer(ctx context.Context, instanceID string,
 		healthCmd = append(healthCmd, cmdPart)
 	}
 
+	labels := map[string]string{
+		"dev-postgres-mcp.managed":     "true",
+		"dev-postgres-mcp.type":        string(m.config.Type),
+		"dev-postgres-mcp.instance-id": instanceID,
+		"dev-postgres-mcp.database":    opts.Database,
+		"dev-postgres-mcp.username":    opts.Username,
+		"dev-postgres-mcp.version":     opts.Version,
+		"dev-postgres-mcp.port":        strconv.Itoa(port),
+		"dev-postgres-mcp.created-at":  time.Now().UTC().Format(time.RFC3339),
+		"dev-postgres-mcp.memory":      strconv.FormatInt(limits.Memory, 10),
+		"dev-postgres-mcp.cpus":        strconv.FormatFloat(limits.CPUs, 'f', -1, 64),
+	}
+	if m.ownerID != "" {
+		labels["dev-postgres-mcp.owner"] = m.ownerID
+	}
+	session := types.SessionFromContext(ctx)
+	if session != "" {
+		labels["dev-postgres-mcp.session"] = session
+	}
+	principal := principalName(ctx)
+	if principal != "" {
+		labels["dev-postgres-mcp.principal"] = principal
+	}
+	var expiresAt *time.Time
+	if opts.TTL > 0 {
+		expires := time.Now().UTC().Add(opts.TTL).Truncate(time.Second)
+		expiresAt = &expires
+		labels["dev-postgres-mcp.expires-at"] = expires.Format(time.RFC3339)
+	}
+	var volumes map[string]string
+	if volumeName != "" {
+		volumes = map[string]string{volumeName: m.config.DataDir}
+		labels["dev-postgres-mcp.volume"] = volumeName
+	}
+	if limits.ShmSize > 0 {
+		labels["dev-postgres-mcp.shm-size"] = strconv.FormatInt(limits.ShmSize, 10)
+	}
+	var tmpfs []string
+	if limits.Tmpfs {
+		tmpfs = []string{m.config.DataDir}
+		labels["dev-postgres-mcp.tmpfs"] = "true"
+	}
+
 	// Create container using the generic Docker client
+	types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhaseCreate, Message: fmt.Sprintf("Creating container %s", containerName)})
 	containerID, err := m.docker.CreateGenericContainer(ctx, docker.GenericContainerConfig{
 		Image:         image,
 		ContainerName: containerName,
 		Environment:   env,
+		Command:       command,
 		Port:          port,
 		ContainerPort: m.config.ContainerPort,
 		HealthCheck:   healthCmd,
-		Labels: map[string]string{
-			"dev-postgres-mcp.managed":     "true",
-			"dev-postgres-mcp.type":        string(m.config.Type),
-			"dev-postgres-mcp.instance-id": instanceID,
-			"dev-postgres-mcp.database":    opts.Database,
-			"dev-postgres-mcp.username":    opts.Username,
-			"dev-postgres-mcp.version":     opts.Version,
-			"dev-postgres-mcp.port":        strconv.Itoa(port),
-			"dev-postgres-mcp.created-at":  time.Now().UTC().Format(time.RFC3339),
-		},
+		Memory:        limits.Memory,
+		CPUs:          limits.CPUs,
+		ShmSize:       limits.ShmSize,
+		Labels:        labels,
+		Volumes:       volumes,
+		Tmpfs:         tmpfs,
 	})
 	if err != nil {
+		// The daemon may have created the container before the request was cancelled
+		if ctx.Err() != nil {
+			m.removeFailedContainer(ctx, containerName)
+		}
 		return nil, fmt.Errorf("failed to create %s container: %w", m.config.Type, err)
 	}
 
 	// Start container
+	types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhaseStart, Message: fmt.Sprintf("Starting container %s", containerName)})
 	if err := m.docker.StartContainer(ctx, containerID); err != nil {
 		// Clean up on failure
-		_ = m.docker.RemoveContainer(ctx, containerID)
+		m.removeFailedContainer(ctx, containerID)
 		return nil, fmt.Errorf("failed to start %s container: %w", m.config.Type, err)
 	}
 
 	// Wait for container to be healthy
-	if err := m.waitForHealthy(ctx, containerID, 120*time.Second); err != nil {
+	status := "running"
+	if opts.NoWait {
+		status = "starting"
+	} else if err := m.waitForHealthy(ctx, containerID, 120*time.Second); err != nil {
 		// Clean up on failure
-		_ = m.docker.RemoveContainer(ctx, containerID)
-		return nil, fmt.Errorf("%s container failed to become healthy: %w", m.config.Type, err)
+		m.removeFailedContainer(ctx, containerID)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("creating %s container cancelled: %w", m.config.Type, ctx.Err())
+		}
+		return nil, fmt.Errorf("%s container: %w: %w", m.config.Type, types.ErrInstanceUnhealthy, err)
 	}
 
 	// Create instance object
@@ -491,10 +768,36 @@ func (m *GenericManager) createContainer(ctx context.Context, instanceID string,
 		Password:    opts.Password,
 		Version:     opts.Version,
 		CreatedAt:   time.Now(),
-		Status:      "running",
+		Status:      status,
+		Owner:       m.ownerID,
+		Session:     session,
+		Principal:   principal,
+		ExpiresAt:   expiresAt,
+		Volume:      volumeName,
+		Resources:   &limits,
 	}
 	instance.DSN = types.BuildDSN(instance)
 
+	// Run the engine's setup command, e.g. to create the requested database
+	if len(m.config.setupTemplates) > 0 && !opts.NoWait {
+		types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhaseSetup, Message: fmt.Sprintf("Setting up database %s", opts.Database)})
+		if err := m.runClientTool(ctx, instance, "setup", m.config.setupTemplates, nil, nil); err != nil {
+			m.removeFailedContainer(ctx, containerID)
+			return nil, err
+		}
+	}
+
+	if len(scripts) > 0 {
+		types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhaseSetup, Fraction: 0.5, Message: fmt.Sprintf("Running %d init scripts", len(scripts))})
+		instance.InitScripts = m.applyInitScripts(ctx, instance, scripts)
+	}
+
+	// A request cancelled during the setup leaves a half-initialized database behind
+	if ctx.Err() != nil {
+		m.removeFailedContainer(ctx, containerID)
+		return nil, fmt.Errorf("creating %s container cancelled: %w", m.config.Type, ctx.Err())
+	}
+
 	slog.Info("Database container created and started successfully",
 		"type", m.config.Type,
 		"instance_id", instanceID,
@@ -504,11 +807,40 @@ func (m *GenericManager) createContainer(ctx context.Context, instanceID string,
 	return instance, nil
 }
 
+// removeFailedContainer removes the container of an instance whose creation failed. The removal
+// outlives the request's context, so that a cancelled request does not leave the container behind.
+func (m *GenericManager) removeFailedContainer(ctx context.Context, container string) {
+	if err := m.docker.RemoveContainer(context.WithoutCancel(ctx), container); err != nil {
+		slog.Warn("Failed to remove container", "type", m.config.Type, "container", container, "error", err)
+	}
+}
+
 // listContainers lists all containers of this database type.
 func (m *GenericManager) listContainers(ctx context.Context) ([]container.Summary, error) {
 	return m.docker.ListContainersByType(ctx, m.config.Type)
 }
 
+// containerStatus returns the instance status of a listed container, including the health of
+// running containers.
+func containerStatus(cont container.Summary) string {
+	if len(cont.Names) == 0 {
+		return "unknown"
+	}
+
+	switch {
+	case cont.State == "exited":
+		return "stopped"
+	case cont.State != "running":
+		return cont.State
+	case strings.Contains(cont.Status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(cont.Status, "(health: starting)"):
+		return "starting"
+	default:
+		return "running"
+	}
+}
+
 // getContainerStatus returns the status of a container.
 func (m *GenericManager) getContainerStatus(ctx context.Context, containerID string) (string, error) {
 	inspect, err := m.docker.InspectContainer(ctx, containerID)
@@ -539,8 +871,10 @@ func (m *GenericManager) getContainerStatus(ctx context.Context, containerID str
 
 // waitForHealthy waits for a container to become healthy.
 func (m *GenericManager) waitForHealthy(ctx context.Context, containerID string, timeout time.Duration) error {
+	parent := ctx
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	started := time.Now()
 
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
@@ -550,8 +884,18 @@ func (m *GenericManager) waitForHealthy(ctx context.Context, containerID string,
 	for {
 		select {
 		case <-ctx.Done():
+			if parent.Err() != nil {
+				return fmt.Errorf("cancelled waiting for container to become healthy: %w", parent.Err())
+			}
 			return fmt.Errorf("timeout waiting for container to become healthy: %w", ctx.Err())
 		case <-ticker.C:
+			elapsed := time.Since(started)
+			types.ReportProgress(ctx, types.Progress{
+				Phase:    types.ProgressPhaseHealth,
+				Fraction: elapsed.Seconds() / timeout.Seconds(),
+				Message:  fmt.Sprintf("Waiting for %s to become healthy (%s)", m.config.Type, elapsed.Round(time.Second)),
+			})
+
 			inspect, err := m.docker.InspectContainer(ctx, containerID)
 			if err != nil {
 				return fmt.Errorf("failed to inspect container: %w", err)
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// HealthCheck checks that an instance's container is healthy and that the database accepts
+// connections with the instance's credentials. For a healthy instance it also reports the
+// connection latency, server version, uptime and number of active connections.
+func (m *GenericManager) HealthCheck(ctx context.Context, id string) (*types.HealthCheckResult, error) {
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result := m.checkHealth(ctx, instance)
+	result.Duration = time.Since(start).String()
+	result.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	return result, nil
+}
+
+// checkHealth performs the checks of HealthCheck, from the container to the database server.
+func (m *GenericManager) checkHealth(ctx context.Context, instance *types.DatabaseInstance) *types.HealthCheckResult {
+	result := &types.HealthCheckResult{}
+	checker := m.docker.HealthChecker()
+
+	containerHealth, err := checker.CheckContainerHealth(ctx, instance.ContainerID)
+	if err != nil {
+		result.Status = types.HealthStatusUnknown
+		result.Message = fmt.Sprintf("Failed to check status: %v", err)
+		return result
+	}
+
+	// Docker and instance health statuses share their values
+	result.ContainerStatus = string(containerHealth.Status)
+	if containerHealth.Status != docker.HealthStatusHealthy {
+		result.Status = types.HealthStatus(containerHealth.Status)
+		result.Message = fmt.Sprintf("%s instance: %s", m.config.Type, containerHealth.Message)
+		return result
+	}
+
+	if m.config.DriverName == "" {
+		result.Status = types.HealthStatusHealthy
+		result.Message = fmt.Sprintf("%s container is healthy (connection checks are not supported)", m.config.Type)
+		return result
+	}
+	if instance.Password == "" {
+		result.Status = types.HealthStatusUnknown
+		result.Message = fmt.Sprintf("%s container is healthy, but the instance password is not known so the connection could not be checked", m.config.Type)
+		return result
+	}
+
+	connection, err := checker.CheckConnection(ctx, m.config.DriverName, types.BuildDSN(instance))
+	if err != nil {
+		result.Status = types.HealthStatusUnknown
+		result.Message = fmt.Sprintf("Failed to check connection: %v", err)
+		return result
+	}
+
+	result.Latency = connection.Duration.String()
+	if connection.Status != docker.HealthStatusHealthy {
+		result.Status = types.HealthStatusUnhealthy
+		result.Message = fmt.Sprintf("%s container is healthy, but the database refused the instance credentials: %s", m.config.Type, connection.Message)
+		return result
+	}
+
+	m.collectServerInfo(ctx, instance, result)
+
+	result.Status = types.HealthStatusHealthy
+	result.Message = fmt.Sprintf("%s instance is running and accepting connections", m.config.Type)
+	return result
+}
+
+// collectServerInfo fills in the server version, uptime and active connections of a healthy
+// instance. These details are best effort; failures are logged and leave the field empty.
+func (m *GenericManager) collectServerInfo(ctx context.Context, instance *types.DatabaseInstance, result *types.HealthCheckResult) {
+	db, err := m.openDB(instance)
+	if err != nil {
+		slog.Warn("Failed to open connection for health details", "instance_id", instance.ID, "error", err)
+		return
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if m.config.VersionQuery != "" {
+		version, err := queryLastColumn(ctx, db, m.config.VersionQuery)
+		if err != nil {
+			slog.Warn("Failed to query server version", "instance_id", instance.ID, "error", err)
+		} else {
+			result.ServerVersion = version
+		}
+	}
+
+	if m.config.UptimeQuery != "" {
+		uptime, err := queryLastColumn(ctx, db, m.config.UptimeQuery)
+		if err == nil {
+			var seconds int64
+			seconds, err = strconv.ParseInt(uptime, 10, 64)
+			if err == nil {
+				result.Uptime = (time.Duration(seconds) * time.Second).String()
+			}
+		}
+		if err != nil {
+			slog.Warn("Failed to query server uptime", "instance_id", instance.ID, "error", err)
+		}
+	}
+
+	if m.config.ConnectionsQuery != "" {
+		var count int
+		if err := db.QueryRowContext(ctx, m.config.ConnectionsQuery).Scan(&count); err != nil {
+			slog.Warn("Failed to count active connections", "instance_id", instance.ID, "error", err)
+		} else {
+			result.ActiveConnections = &count
+		}
+	}
+}
+
+// queryLastColumn runs a query returning a single row and returns its last column as a string,
+// which allows statements such as SHOW GLOBAL STATUS that return name/value pairs.
+func queryLastColumn(ctx context.Context, db *sql.DB, query string) (string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("query returned no columns")
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", sql.ErrNoRows
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+
+	return values[len(values)-1].String, nil
+}
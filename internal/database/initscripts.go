@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+const (
+	// maxInitScriptBytes limits the size of a single init script.
+	maxInitScriptBytes = 64 << 20
+
+	// initScriptFetchTimeout limits how long fetching an init script from a URL may take.
+	initScriptFetchTimeout = 60 * time.Second
+)
+
+// InitScript is an init script resolved to the SQL it contains.
+type InitScript struct {
+	// Source describes where the script came from: a file path, a URL, or "inline[N]".
+	Source string
+
+	// SQL is the script content.
+	SQL string
+}
+
+// ResolveInitScripts resolves init script specifications into SQL scripts, in order.
+// Each specification is one of:
+//   - an http:// or https:// URL, whose response body is the script;
+//   - a path to a file on the host;
+//   - a path to a directory on the host, whose *.sql files are used in lexical order;
+//   - inline SQL.
+//
+// A specification that looks like a path (a single word ending in .sql or containing a path
+// separator) but does not exist is an error rather than being treated as inline SQL.
+//
+// If the policy is restricted, the request comes from a remote client, which must not make the
+// server read its own files or fetch arbitrary URLs: paths and URLs are rejected unless they
+// fall under the policy's allowed directories and hosts.
+func ResolveInitScripts(ctx context.Context, specs []string, policy types.InitScriptPolicy) ([]InitScript, error) {
+	var scripts []InitScript
+
+	restricted := policy.Restricted
+	client := http.DefaultClient
+	var allowed func(path string) bool
+	if restricted {
+		client = &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after 10 redirects")
+			}
+			if !hostAllowed(req.URL.Hostname(), policy.AllowedHosts) {
+				return fmt.Errorf("redirect to %s is not allowed", req.URL.Host)
+			}
+			return nil
+		}}
+		allowed = func(path string) bool {
+			return pathAllowed(path, policy.AllowedDirs)
+		}
+	}
+
+	for i, spec := range specs {
+		if strings.TrimSpace(spec) == "" {
+			return nil, fmt.Errorf("init script %d is empty", i+1)
+		}
+
+		if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+			if restricted && !urlAllowed(spec, policy.AllowedHosts) {
+				return nil, fmt.Errorf("init script %s: URLs are not allowed for remote clients unless their host is allowed by the server", spec)
+			}
+			sql, err := fetchInitScript(ctx, client, spec)
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, InitScript{Source: spec, SQL: sql})
+			continue
+		}
+
+		// Outside the allowed directories, remote clients only get inline SQL; the host's
+		// file system is not even checked for the path
+		if restricted && !allowed(spec) {
+			if looksLikePath(spec) {
+				return nil, fmt.Errorf("init script %s: host paths are not allowed for remote clients unless they are in a directory allowed by the server", spec)
+			}
+			scripts = append(scripts, InitScript{Source: fmt.Sprintf("inline[%d]", i+1), SQL: spec})
+			continue
+		}
+
+		info, err := os.Stat(spec)
+		switch {
+		case err == nil && info.IsDir():
+			dirScripts, err := readInitScriptDir(spec, allowed)
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, dirScripts...)
+		case err == nil:
+			sql, err := readInitScriptFile(spec)
+			if err != nil {
+				return nil, err
+			}
+			scripts = append(scripts, InitScript{Source: spec, SQL: sql})
+		case looksLikePath(spec):
+			return nil, fmt.Errorf("init script %s: %w", spec, err)
+		default:
+			scripts = append(scripts, InitScript{Source: fmt.Sprintf("inline[%d]", i+1), SQL: spec})
+		}
+	}
+
+	return scripts, nil
+}
+
+// applyInitScripts runs init scripts against a new instance in order, stopping at the first
+// failure. Scripts after a failed one are reported as skipped.
+func (m *GenericManager) applyInitScripts(ctx context.Context, instance *types.DatabaseInstance, scripts []InitScript) []types.InitScriptResult {
+	results := make([]types.InitScriptResult, 0, len(scripts))
+	failed := false
+
+	for _, script := range scripts {
+		if failed {
+			results = append(results, types.InitScriptResult{Source: script.Source, Status: types.InitScriptSkipped})
+			continue
+		}
+
+		slog.Info("Applying init script", "type", m.config.Type, "instance_id", instance.ID, "source", script.Source)
+
+		start := time.Now()
+		err := m.runClientTool(ctx, instance, "init script", m.config.restoreTemplates, strings.NewReader(script.SQL), nil)
+		result := types.InitScriptResult{
+			Source:   script.Source,
+			Status:   types.InitScriptApplied,
+			Duration: time.Since(start).String(),
+		}
+		if err != nil {
+			slog.Warn("Init script failed", "type", m.config.Type, "instance_id", instance.ID, "source", script.Source, "error", err)
+			result.Status = types.InitScriptFailed
+			result.Error = err.Error()
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// readInitScriptDir reads the *.sql files of a directory in lexical order. If allowed is not
+// nil, every file must be allowed by it, so that symlinks cannot escape an allowed directory.
+func readInitScriptDir(dir string, allowed func(path string) bool) ([]InitScript, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read init script directory %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("init script directory %s contains no .sql files", dir)
+	}
+
+	scripts := make([]InitScript, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if allowed != nil && !allowed(path) {
+			return nil, fmt.Errorf("init script %s is outside the directories allowed by the server", path)
+		}
+		sql, err := readInitScriptFile(path)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, InitScript{Source: path, SQL: sql})
+	}
+
+	return scripts, nil
+}
+
+// readInitScriptFile reads an init script from a file.
+func readInitScriptFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open init script %s: %w", path, err)
+	}
+	defer file.Close()
+
+	return readInitScript(path, file)
+}
+
+// fetchInitScript downloads an init script from a URL.
+func fetchInitScript(ctx context.Context, client *http.Client, rawURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, initScriptFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid init script URL %s: %w", rawURL, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch init script %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch init script %s: %s", rawURL, resp.Status)
+	}
+
+	return readInitScript(rawURL, resp.Body)
+}
+
+// readInitScript reads a script, enforcing the size limit.
+func readInitScript(source string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxInitScriptBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read init script %s: %w", source, err)
+	}
+	if len(data) > maxInitScriptBytes {
+		return "", fmt.Errorf("init script %s exceeds %d bytes", source, maxInitScriptBytes)
+	}
+	return string(data), nil
+}
+
+// looksLikePath reports whether an init script specification that does not exist on disk was
+// most likely meant as a path rather than inline SQL.
+func looksLikePath(spec string) bool {
+	if strings.ContainsAny(spec, " \t\r\n;") {
+		return false
+	}
+	return strings.EqualFold(filepath.Ext(spec), ".sql") || strings.ContainsRune(spec, '/') || strings.ContainsRune(spec, filepath.Separator)
+}
+
+// urlAllowed reports whether a URL's host is one of the allowed hosts.
+func urlAllowed(rawURL string, hosts []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return hostAllowed(u.Hostname(), hosts)
+}
+
+// hostAllowed reports whether host is one of the allowed hosts.
+func hostAllowed(host string, hosts []string) bool {
+	for _, allowed := range hosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathAllowed reports whether path, with symlinks resolved, is inside one of the allowed directories.
+func pathAllowed(path string, dirs []string) bool {
+	resolved, err := resolvePath(path)
+	if err != nil {
+		return false
+	}
+
+	for _, dir := range dirs {
+		resolvedDir, err := resolvePath(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(resolvedDir, resolved)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePath returns the absolute form of path with symlinks resolved if it exists.
+func resolvePath(path string) (string, error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Abs(path)
+}
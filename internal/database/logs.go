@@ -0,0 +1,162 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// Severity markers written by the supported engines, e.g. "ERROR:  relation ... does not exist"
+// (PostgreSQL) or "[ERROR] [MY-010119] [Server] ..." (MySQL, MariaDB).
+var (
+	errorLogLine   = regexp.MustCompile(`\b(ERROR|FATAL|PANIC):|\[(ERROR|Error)\]`)
+	warningLogLine = regexp.MustCompile(`\bWARNING:|\[(WARNING|Warning|Warn)\]`)
+)
+
+// StreamLogs copies an instance's container logs to the writers in opts.
+func (m *GenericManager) StreamLogs(ctx context.Context, id string, opts types.LogOptions) error {
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return m.docker.StreamContainerLogs(ctx, instance.ContainerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Since:      opts.Since,
+		Tail:       opts.Tail,
+		Timestamps: opts.Timestamps,
+	}, opts.Stdout, opts.Stderr)
+}
+
+// StreamLogs copies an instance's container logs to the writers in opts.
+func (m *UnifiedManager) StreamLogs(ctx context.Context, id string, opts types.LogOptions) error {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return manager.StreamLogs(ctx, instance.ID, opts)
+}
+
+// GetLogs returns up to filter.Tail of an instance's most recent log lines. Zero or
+// out-of-range tails are replaced by types.DefaultLogTail or clamped to types.MaxLogTail.
+// With a level or pattern filter, the last types.MaxLogScanLines lines are searched.
+func (m *UnifiedManager) GetLogs(ctx context.Context, id string, filter types.LogFilter) (*types.LogsResult, error) {
+	switch {
+	case filter.Tail <= 0:
+		filter.Tail = types.DefaultLogTail
+	case filter.Tail > types.MaxLogTail:
+		filter.Tail = types.MaxLogTail
+	}
+
+	// Validate the filter before reading any logs
+	if _, err := FilterLogLines(nil, filter.Level, filter.Pattern); err != nil {
+		return nil, err
+	}
+
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := filter.Tail
+	if filter.Level != "" || filter.Pattern != "" {
+		scan = types.MaxLogScanLines
+	}
+
+	// Both streams go to the same buffer to keep lines in the order they were written
+	var logs bytes.Buffer
+	if err := manager.StreamLogs(ctx, instance.ID, types.LogOptions{
+		Since:      filter.Since,
+		Tail:       strconv.Itoa(scan),
+		Timestamps: filter.Timestamps,
+		Stdout:     &logs,
+		Stderr:     &logs,
+	}); err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	if text := strings.TrimRight(logs.String(), "\n"); text != "" {
+		lines = strings.Split(text, "\n")
+	}
+
+	matched, err := FilterLogLines(lines, filter.Level, filter.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.LogsResult{
+		InstanceID: instance.ID,
+		Lines:      matched,
+		Scanned:    len(lines),
+	}
+	if len(matched) > filter.Tail {
+		result.Lines = matched[len(matched)-filter.Tail:]
+		result.Truncated = true
+	}
+	if result.Lines == nil {
+		result.Lines = []string{}
+	}
+
+	return result, nil
+}
+
+// FilterLogLines returns the lines of at least the given severity (error, warning, info;
+// empty means all) that match pattern (empty matches all).
+func FilterLogLines(lines []string, level, pattern string) ([]string, error) {
+	var severity int
+	switch strings.ToLower(level) {
+	case "", types.LogLevelInfo:
+		severity = 0
+	case types.LogLevelWarning:
+		severity = 1
+	case types.LogLevelError:
+		severity = 2
+	default:
+		return nil, fmt.Errorf("unsupported log level: %s (expected %s, %s or %s)", level, types.LogLevelError, types.LogLevelWarning, types.LogLevelInfo)
+	}
+
+	var re *regexp.Regexp
+	if pattern != "" {
+		var err error
+		re, err = regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log pattern: %w", err)
+		}
+	}
+
+	var matched []string
+	for _, line := range lines {
+		if logLineSeverity(line) < severity {
+			continue
+		}
+		if re != nil && !re.MatchString(line) {
+			continue
+		}
+		matched = append(matched, line)
+	}
+
+	return matched, nil
+}
+
+// logLineSeverity classifies a log line as 2 (error), 1 (warning) or 0 (anything else).
+func logLineSeverity(line string) int {
+	switch {
+	case errorLogLine.MatchString(line):
+		return 2
+	case warningLogLine.MatchString(line):
+		return 1
+	default:
+		return 0
+	}
+}
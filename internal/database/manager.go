@@ -5,33 +5,54 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 
 	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
 	"github.com/stokaro/dev-postgres-mcp/pkg/types"
 )
 
 // UnifiedManager manages database instances across multiple database types.
 type UnifiedManager struct {
-	mu        sync.RWMutex
-	instances map[string]*types.DatabaseInstance
-	docker    *docker.Manager
-	managers  map[types.DatabaseType]types.DatabaseManager
+	mu          sync.RWMutex
+	instances   map[string]*types.DatabaseInstance
+	docker      *docker.Manager
+	managers    map[types.DatabaseType]types.DatabaseManager
+	engineTypes []types.DatabaseType // The managers' types in a fixed order
+	snapshots   *store.SnapshotStore
+	ownerID     string
+	resources   types.ResourcePolicy
+	quotas      *quotaTracker
+
+	listenersMu sync.Mutex
+	listeners   []func(types.InstanceEvent)
 }
 
-// NewUnifiedManager creates a new unified database manager.
+// NewUnifiedManager creates a new unified database manager using DefaultManagerConfig.
 func NewUnifiedManager(dockerManager *docker.Manager) *UnifiedManager {
+	return NewUnifiedManagerWithConfig(dockerManager, DefaultManagerConfig())
+}
+
+// NewUnifiedManagerWithConfig creates a new unified database manager using the given configuration.
+func NewUnifiedManagerWithConfig(dockerManager *docker.Manager, config ManagerConfig) *UnifiedManager {
 	managers := make(map[types.DatabaseType]types.DatabaseManager)
 
-	// Create database-specific managers using the generic manager
-	managers[types.DatabaseTypePostgreSQL] = NewGenericManager(dockerManager, types.DatabaseTypePostgreSQL)
-	managers[types.DatabaseTypeMySQL] = NewGenericManager(dockerManager, types.DatabaseTypeMySQL)
-	managers[types.DatabaseTypeMariaDB] = NewGenericManager(dockerManager, types.DatabaseTypeMariaDB)
+	// Create a generic manager for every registered engine
+	engineTypes := types.EngineTypes()
+	for _, dbType := range engineTypes {
+		managers[dbType] = NewGenericManagerWithConfig(dockerManager, dbType, config)
+	}
 
 	return &UnifiedManager{
-		instances: make(map[string]*types.DatabaseInstance),
-		docker:    dockerManager,
-		managers:  managers,
+		instances:   make(map[string]*types.DatabaseInstance),
+		docker:      dockerManager,
+		managers:    managers,
+		engineTypes: engineTypes,
+		snapshots:   config.Snapshots,
+		ownerID:     config.OwnerID,
+		resources:   config.Resources,
+		quotas:      &quotaTracker{policy: config.Quotas},
 	}
 }
 
@@ -48,6 +69,13 @@ func (m *UnifiedManager) CreateInstance(ctx context.Context, opts types.CreateIn
 		return nil, fmt.Errorf("unsupported database type: %s", opts.Type)
 	}
 
+	// Reserve the instance's share of the quotas until it exists
+	release, err := m.reserveQuota(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// Create the instance
 	instance, err := manager.CreateInstance(ctx, opts)
 	if err != nil {
@@ -59,6 +87,8 @@ func (m *UnifiedManager) CreateInstance(ctx context.Context, opts types.CreateIn
 	m.instances[instance.ID] = instance
 	m.mu.Unlock()
 
+	m.publish(types.InstanceEvent{Type: types.InstanceEventCreated, InstanceID: instance.ID, Status: instance.Status})
+
 	slog.Info("Database instance created",
 		"instance_id", instance.ID,
 		"type", instance.Type,
@@ -68,8 +98,18 @@ func (m *UnifiedManager) CreateInstance(ctx context.Context, opts types.CreateIn
 	return instance, nil
 }
 
-// ListInstances returns all database instances across all types.
+// ListInstances returns all database instances across all types that the principal in the
+// context may access.
 func (m *UnifiedManager) ListInstances(ctx context.Context) ([]*types.DatabaseInstance, error) {
+	instances, err := m.listAllInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterByPrincipal(ctx, instances), nil
+}
+
+// listAllInstances returns the instances of all principals and refreshes the in-memory registry.
+func (m *UnifiedManager) listAllInstances(ctx context.Context) ([]*types.DatabaseInstance, error) {
 	var allInstances []*types.DatabaseInstance
 
 	// Get instances from each database manager
@@ -84,12 +124,15 @@ func (m *UnifiedManager) ListInstances(ctx context.Context) ([]*types.DatabaseIn
 
 	// Update in-memory registry
 	m.mu.Lock()
+	previous := m.instances
 	m.instances = make(map[string]*types.DatabaseInstance)
 	for _, instance := range allInstances {
 		m.instances[instance.ID] = instance
 	}
 	m.mu.Unlock()
 
+	m.publishChanges(previous, allInstances)
+
 	return allInstances, nil
 }
 
@@ -100,40 +143,72 @@ func (m *UnifiedManager) ListInstancesByType(ctx context.Context, dbType types.D
 		return nil, fmt.Errorf("unsupported database type: %s", dbType)
 	}
 
-	return manager.ListInstances(ctx)
+	instances, err := manager.ListInstances(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterByPrincipal(ctx, instances), nil
 }
 
-// GetInstance returns a specific database instance by ID.
+// filterByPrincipal returns the instances that the principal in the context may access.
+func filterByPrincipal(ctx context.Context, instances []*types.DatabaseInstance) []*types.DatabaseInstance {
+	principal := types.PrincipalFromContext(ctx)
+	accessible := make([]*types.DatabaseInstance, 0, len(instances))
+	for _, instance := range instances {
+		if principal.CanAccess(instance.Principal) {
+			accessible = append(accessible, instance)
+		}
+	}
+	return accessible
+}
+
+// GetInstance returns a specific database instance by ID or unique ID prefix. Only the instances
+// that the principal in the context may access are matched, so the others are reported as not
+// found and do not make a prefix ambiguous.
 func (m *UnifiedManager) GetInstance(ctx context.Context, id string) (*types.DatabaseInstance, error) {
+	principal := types.PrincipalFromContext(ctx)
+
 	// First try exact match in-memory instances
 	m.mu.RLock()
-	if instance, exists := m.instances[id]; exists {
-		m.mu.RUnlock()
-
+	instance, exists := m.instances[id]
+	m.mu.RUnlock()
+	if exists && principal.CanAccess(instance.Principal) {
 		// Get the appropriate manager and update status
-		manager, exists := m.managers[instance.Type]
-		if exists {
-			if updatedInstance, err := manager.GetInstance(ctx, id); err == nil {
+		if manager, exists := m.managers[instance.Type]; exists {
+			if updatedInstance, err := manager.GetInstance(ctx, instance.ID); err == nil {
 				return updatedInstance, nil
 			}
 		}
-
 		return instance, nil
 	}
-	m.mu.RUnlock()
 
-	// Try to find in each database manager (supports partial ID matching)
-	for _, manager := range m.managers {
-		if instance, err := manager.GetInstance(ctx, id); err == nil {
-			// Update in-memory registry
-			m.mu.Lock()
-			m.instances[instance.ID] = instance
-			m.mu.Unlock()
-			return instance, nil
+	// Match the accessible instances of each engine in a fixed order (supports partial IDs)
+	var matches []*types.DatabaseInstance
+	for _, dbType := range m.engineTypes {
+		instances, err := m.managers[dbType].ListInstances(ctx)
+		if err != nil {
+			slog.Warn("Failed to list instances for database type", "type", dbType, "error", err)
+			continue
+		}
+		for _, instance := range filterByPrincipal(ctx, instances) {
+			if strings.HasPrefix(instance.ID, id) {
+				matches = append(matches, instance)
+			}
 		}
 	}
 
-	return nil, fmt.Errorf("instance %s not found", id)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("instance %s not found", id)
+	case 1:
+		// Update in-memory registry
+		m.mu.Lock()
+		m.instances[matches[0].ID] = matches[0]
+		m.mu.Unlock()
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple instances match %s", id)
+	}
 }
 
 // DropInstance removes a database instance.
@@ -151,22 +226,103 @@ func (m *UnifiedManager) DropInstance(ctx context.Context, id string) error {
 	}
 
 	// Drop the instance
-	if err := manager.DropInstance(ctx, id); err != nil {
+	if err := manager.DropInstance(ctx, instance.ID); err != nil {
 		return err
 	}
 
 	// Remove from in-memory registry
 	m.mu.Lock()
-	delete(m.instances, id)
+	delete(m.instances, instance.ID)
 	m.mu.Unlock()
 
+	m.publish(types.InstanceEvent{Type: types.InstanceEventDropped, InstanceID: instance.ID})
+
 	slog.Info("Database instance dropped",
-		"instance_id", id,
+		"instance_id", instance.ID,
 		"type", instance.Type)
 
 	return nil
 }
 
+// StopInstance stops a database instance without removing its container or data.
+func (m *UnifiedManager) StopInstance(ctx context.Context, id string) error {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := manager.StopInstance(ctx, instance.ID); err != nil {
+		return err
+	}
+	m.setStatus(instance, "stopped")
+
+	slog.Info("Database instance stopped", "instance_id", instance.ID, "type", instance.Type)
+	return nil
+}
+
+// StartInstance starts a stopped database instance and waits for it to become healthy.
+func (m *UnifiedManager) StartInstance(ctx context.Context, id string) error {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// A stopped instance's memory counts towards the quota again once it runs
+	release, err := m.reserveStartQuota(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := manager.StartInstance(ctx, instance.ID); err != nil {
+		return err
+	}
+	m.setStatus(instance, "running")
+
+	slog.Info("Database instance started", "instance_id", instance.ID, "type", instance.Type)
+	return nil
+}
+
+// RestartInstance stops and starts a database instance.
+func (m *UnifiedManager) RestartInstance(ctx context.Context, id string) error {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// A stopped instance's memory counts towards the quota again once it runs
+	release, err := m.reserveStartQuota(ctx, instance)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := manager.RestartInstance(ctx, instance.ID); err != nil {
+		return err
+	}
+	m.setStatus(instance, "running")
+
+	slog.Info("Database instance restarted", "instance_id", instance.ID, "type", instance.Type)
+	return nil
+}
+
+// instanceManager resolves an instance ID (or prefix) and returns the manager responsible for it.
+func (m *UnifiedManager) instanceManager(ctx context.Context, id string) (types.DatabaseManager, *types.DatabaseInstance, error) {
+	// Get the instance to determine its type
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get the appropriate manager
+	manager, exists := m.managers[instance.Type]
+	if !exists {
+		return nil, nil, fmt.Errorf("unsupported database type: %s", instance.Type)
+	}
+
+	return manager, instance, nil
+}
+
 // HealthCheck performs a health check on a database instance.
 func (m *UnifiedManager) HealthCheck(ctx context.Context, id string) (*types.HealthCheckResult, error) {
 	// Get the instance to determine its type
@@ -181,10 +337,178 @@ func (m *UnifiedManager) HealthCheck(ctx context.Context, id string) (*types.Hea
 		return nil, fmt.Errorf("unsupported database type: %s", instance.Type)
 	}
 
-	return manager.HealthCheck(ctx, id)
+	return manager.HealthCheck(ctx, instance.ID)
 }
 
-// Cleanup removes all instances managed by this manager.
+// ExecuteSQL executes a SQL statement against a database instance.
+func (m *UnifiedManager) ExecuteSQL(ctx context.Context, id string, query string, opts types.QueryOptions) (*types.QueryResult, error) {
+	// Get the instance to determine its type
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the appropriate manager
+	manager, exists := m.managers[instance.Type]
+	if !exists {
+		return nil, fmt.Errorf("unsupported database type: %s", instance.Type)
+	}
+
+	return manager.ExecuteSQL(ctx, instance.ID, query, opts)
+}
+
+// DescribeSchema returns the tables in an instance's database with their columns.
+func (m *UnifiedManager) DescribeSchema(ctx context.Context, id string) ([]types.TableSchema, error) {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.DescribeSchema(ctx, instance.ID)
+}
+
+// ActiveConnections returns the number of client connections to a database instance.
+func (m *UnifiedManager) ActiveConnections(ctx context.Context, id string) (int, error) {
+	// Get the instance to determine its type
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the appropriate manager
+	manager, exists := m.managers[instance.Type]
+	if !exists {
+		return 0, fmt.Errorf("unsupported database type: %s", instance.Type)
+	}
+
+	return manager.ActiveConnections(ctx, instance.ID)
+}
+
+// DetachInstance removes a persistent instance's container but keeps its data volume.
+func (m *UnifiedManager) DetachInstance(ctx context.Context, id string) (*types.VolumeInfo, error) {
+	// Get the instance to determine its type
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the appropriate manager
+	manager, exists := m.managers[instance.Type]
+	if !exists {
+		return nil, fmt.Errorf("unsupported database type: %s", instance.Type)
+	}
+
+	info, err := manager.DetachInstance(ctx, instance.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove from in-memory registry
+	m.mu.Lock()
+	delete(m.instances, instance.ID)
+	m.mu.Unlock()
+
+	m.publish(types.InstanceEvent{Type: types.InstanceEventDropped, InstanceID: instance.ID})
+
+	slog.Info("Database instance detached",
+		"instance_id", instance.ID,
+		"type", instance.Type,
+		"volume", info.Name)
+
+	return info, nil
+}
+
+// AttachVolume recreates an instance from a detached data volume.
+func (m *UnifiedManager) AttachVolume(ctx context.Context, name string) (*types.DatabaseInstance, error) {
+	manager, err := m.volumeManager(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reserve the instance's share of the quotas until it exists
+	release, err := m.reserveQuota(ctx, types.CreateInstanceOptions{Type: manager.GetDatabaseType()})
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	instance, err := manager.AttachVolume(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store in unified registry
+	m.mu.Lock()
+	m.instances[instance.ID] = instance
+	m.mu.Unlock()
+
+	m.publish(types.InstanceEvent{Type: types.InstanceEventCreated, InstanceID: instance.ID, Status: instance.Status})
+
+	slog.Info("Database volume attached",
+		"instance_id", instance.ID,
+		"type", instance.Type,
+		"volume", name,
+		"port", instance.Port)
+
+	return instance, nil
+}
+
+// ListVolumes returns the data volumes across all database types that the principal in the
+// context may access.
+func (m *UnifiedManager) ListVolumes(ctx context.Context) ([]*types.VolumeInfo, error) {
+	var allVolumes []*types.VolumeInfo
+
+	for dbType, manager := range m.managers {
+		volumes, err := manager.ListVolumes(ctx)
+		if err != nil {
+			slog.Warn("Failed to list volumes for database type", "type", dbType, "error", err)
+			continue
+		}
+		for _, vol := range volumes {
+			if types.PrincipalFromContext(ctx).CanAccess(vol.Principal) {
+				allVolumes = append(allVolumes, vol)
+			}
+		}
+	}
+
+	return allVolumes, nil
+}
+
+// DeleteVolume removes a detached data volume and all its data.
+func (m *UnifiedManager) DeleteVolume(ctx context.Context, name string) error {
+	manager, err := m.volumeManager(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	return manager.DeleteVolume(ctx, name)
+}
+
+// volumeManager returns the manager responsible for a managed data volume.
+func (m *UnifiedManager) volumeManager(ctx context.Context, name string) (types.DatabaseManager, error) {
+	vol, err := m.docker.InspectVolume(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if vol.Labels["dev-postgres-mcp.managed"] != "true" {
+		return nil, fmt.Errorf("volume %s is not managed by dev-postgres-mcp", name)
+	}
+
+	if !types.PrincipalFromContext(ctx).CanAccess(vol.Labels["dev-postgres-mcp.principal"]) {
+		return nil, fmt.Errorf("volume %s not found", name)
+	}
+
+	dbType := types.DatabaseType(vol.Labels["dev-postgres-mcp.type"])
+	manager, exists := m.managers[dbType]
+	if !exists {
+		return nil, fmt.Errorf("unsupported database type: %s", dbType)
+	}
+
+	return manager, nil
+}
+
+// Cleanup removes the instances selected by the configured cleanup policy.
 func (m *UnifiedManager) Cleanup(ctx context.Context) error {
 	var errors []error
 
@@ -196,10 +520,10 @@ func (m *UnifiedManager) Cleanup(ctx context.Context) error {
 		}
 	}
 
-	// Clear in-memory registry
-	m.mu.Lock()
-	m.instances = make(map[string]*types.DatabaseInstance)
-	m.mu.Unlock()
+	// Refresh in-memory registry, since the cleanup policy may have kept some instances
+	if _, err := m.listAllInstances(ctx); err != nil {
+		slog.Warn("Failed to refresh instances after cleanup", "error", err)
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("cleanup failed for some database types: %v", errors)
@@ -0,0 +1,370 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "github.com/go-sql-driver/mysql" // MySQL and MariaDB driver
+	_ "github.com/lib/pq"              // PostgreSQL driver
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// ExecuteSQL executes a SQL statement against a database instance.
+func (m *GenericManager) ExecuteSQL(ctx context.Context, id string, query string, opts types.QueryOptions) (*types.QueryResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query must not be empty")
+	}
+	if err := types.ValidateQueryOptions(&opts); err != nil {
+		return nil, fmt.Errorf("invalid query options: %w", err)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := m.openDB(instance)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	slog.Info("Executing SQL", "type", m.config.Type, "instance_id", instance.ID, "params", len(opts.Params))
+
+	start := time.Now()
+	var result *types.QueryResult
+	if IsQueryStatement(query) {
+		result, err = runQuery(ctx, db, query, opts)
+	} else {
+		result, err = runExec(ctx, db, query, opts)
+	}
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("statement timed out after %s: %w", opts.Timeout, err)
+		}
+		return nil, err
+	}
+	result.Duration = time.Since(start).String()
+
+	return result, nil
+}
+
+// ActiveConnections returns the number of client connections to a database instance,
+// excluding the connection used to count them.
+func (m *GenericManager) ActiveConnections(ctx context.Context, id string) (int, error) {
+	if m.config.ConnectionsQuery == "" {
+		return 0, fmt.Errorf("%s instances do not support connection counting", m.config.Type)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := m.openDB(instance)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var count int
+	if err := db.QueryRowContext(ctx, m.config.ConnectionsQuery).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count %s connections: %w", m.config.Type, err)
+	}
+
+	return count, nil
+}
+
+// DescribeSchema returns the tables in an instance's database with their columns.
+func (m *GenericManager) DescribeSchema(ctx context.Context, id string) ([]types.TableSchema, error) {
+	if m.config.ColumnsQuery == "" {
+		return nil, fmt.Errorf("%s instances do not support schema introspection", m.config.Type)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := m.openDB(instance)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, m.config.ColumnsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s schema: %w", m.config.Type, err)
+	}
+	defer rows.Close()
+
+	tables := []types.TableSchema{}
+	for rows.Next() {
+		var table, nullable string
+		var column types.ColumnSchema
+		if err := rows.Scan(&table, &column.Name, &column.Type, &nullable); err != nil {
+			return nil, fmt.Errorf("failed to scan %s schema: %w", m.config.Type, err)
+		}
+		column.Nullable = strings.EqualFold(nullable, "YES")
+
+		if len(tables) == 0 || tables[len(tables)-1].Name != table {
+			tables = append(tables, types.TableSchema{Name: table})
+		}
+		tables[len(tables)-1].Columns = append(tables[len(tables)-1].Columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s schema: %w", m.config.Type, err)
+	}
+
+	return tables, nil
+}
+
+// openDB opens a database/sql handle for the given instance.
+func (m *GenericManager) openDB(instance *types.DatabaseInstance) (*sql.DB, error) {
+	if m.config.DriverName == "" {
+		return nil, fmt.Errorf("%s instances do not support SQL execution", m.config.Type)
+	}
+	if instance.Password == "" {
+		return nil, fmt.Errorf("password for %s instance %s is not known", m.config.Type, instance.ID)
+	}
+
+	db, err := sql.Open(m.config.DriverName, types.BuildDSN(instance))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s connection: %w", m.config.Type, err)
+	}
+
+	// A single connection keeps session state (e.g. SET statements) consistent within a call
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(0)
+
+	return db, nil
+}
+
+// runQuery executes a statement that returns rows and collects them up to the configured limits.
+func runQuery(ctx context.Context, db *sql.DB, query string, opts types.QueryOptions) (*types.QueryResult, error) {
+	rows, err := db.QueryContext(ctx, query, opts.Params...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read column types: %w", err)
+	}
+
+	result := &types.QueryResult{
+		Columns: make([]types.QueryColumn, len(columnTypes)),
+		Rows:    [][]any{},
+	}
+	for i, ct := range columnTypes {
+		result.Columns[i] = types.QueryColumn{Name: ct.Name(), Type: ct.DatabaseTypeName()}
+	}
+
+	size := 0
+	for rows.Next() {
+		if len(result.Rows) >= opts.MaxRows {
+			result.Truncated = true
+			result.TruncatedReason = "max_rows"
+			break
+		}
+
+		values := make([]any, len(columnTypes))
+		pointers := make([]any, len(columnTypes))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		row := make([]any, len(values))
+		for i, value := range values {
+			row[i] = convertValue(value)
+		}
+
+		encoded, err := json.Marshal(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode row: %w", err)
+		}
+		if size+len(encoded) > opts.MaxBytes {
+			result.Truncated = true
+			result.TruncatedReason = "max_bytes"
+			break
+		}
+		size += len(encoded)
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+// runExec executes a statement that does not return rows.
+func runExec(ctx context.Context, db *sql.DB, query string, opts types.QueryOptions) (*types.QueryResult, error) {
+	res, err := db.ExecContext(ctx, query, opts.Params...)
+	if err != nil {
+		return nil, fmt.Errorf("statement failed: %w", err)
+	}
+
+	result := &types.QueryResult{
+		Columns: []types.QueryColumn{},
+		Rows:    [][]any{},
+	}
+
+	// Not every driver reports affected rows for every statement (e.g. DDL)
+	if affected, err := res.RowsAffected(); err == nil {
+		result.RowsAffected = affected
+	}
+
+	return result, nil
+}
+
+// convertValue converts a scanned value into a JSON-friendly representation.
+func convertValue(value any) any {
+	switch v := value.(type) {
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+// queryKeywords are the leading keywords of statements that produce a result set.
+var queryKeywords = map[string]bool{
+	"select":   true,
+	"with":     true,
+	"show":     true,
+	"explain":  true,
+	"values":   true,
+	"table":    true,
+	"describe": true,
+	"desc":     true,
+}
+
+// IsQueryStatement reports whether a statement is expected to return rows.
+func IsQueryStatement(query string) bool {
+	statement := strings.ToLower(stripLeadingComments(query))
+	statement = strings.TrimLeft(statement, "( \t\r\n")
+
+	keyword := statement
+	if i := strings.IndexFunc(statement, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z')
+	}); i >= 0 {
+		keyword = statement[:i]
+	}
+
+	if queryKeywords[keyword] {
+		return true
+	}
+
+	// INSERT/UPDATE/DELETE ... RETURNING produce rows as well
+	return hasKeyword(statement, "returning")
+}
+
+// hasKeyword reports whether a lowercased statement contains keyword as a
+// standalone token outside string literals, quoted identifiers and comments.
+func hasKeyword(statement, keyword string) bool {
+	isWordByte := func(b byte) bool {
+		return b == '_' || b == '$' || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9')
+	}
+	// skipTo returns the index just past the next occurrence of closing at or after i.
+	skipTo := func(i int, closing string) int {
+		end := strings.Index(statement[i:], closing)
+		if end < 0 {
+			return len(statement)
+		}
+		return i + end + len(closing)
+	}
+
+	for i := 0; i < len(statement); {
+		switch b := statement[i]; {
+		case b == '\'' || b == '"' || b == '`':
+			// Doubled quotes are escapes; they are consumed as two adjacent literals.
+			i = skipTo(i+1, string(b))
+		case b == '[':
+			i = skipTo(i+1, "]")
+		case strings.HasPrefix(statement[i:], "--"), b == '#':
+			i = skipTo(i, "\n")
+		case strings.HasPrefix(statement[i:], "/*"):
+			i = skipTo(i+2, "*/")
+		case b == '$':
+			// PostgreSQL dollar-quoted string: $tag$ ... $tag$
+			end := strings.IndexByte(statement[i+1:], '$')
+			tag := ""
+			if end >= 0 {
+				tag = statement[i : i+end+2]
+			}
+			if end < 0 || strings.IndexFunc(tag[1:len(tag)-1], func(r rune) bool {
+				return !(r == '_' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'))
+			}) >= 0 {
+				i++
+				continue
+			}
+			i = skipTo(i+len(tag), tag)
+		case isWordByte(b):
+			start := i
+			for i < len(statement) && isWordByte(statement[i]) {
+				i++
+			}
+			if statement[start:i] == keyword {
+				return true
+			}
+		default:
+			i++
+		}
+	}
+	return false
+}
+
+// stripLeadingComments removes leading whitespace and SQL comments from a statement.
+func stripLeadingComments(query string) string {
+	for {
+		query = strings.TrimSpace(query)
+		switch {
+		case strings.HasPrefix(query, "--"), strings.HasPrefix(query, "#"):
+			end := strings.IndexByte(query, '\n')
+			if end < 0 {
+				return ""
+			}
+			query = query[end+1:]
+		case strings.HasPrefix(query, "/*"):
+			end := strings.Index(query, "*/")
+			if end < 0 {
+				return ""
+			}
+			query = query[end+2:]
+		default:
+			return query
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// quotaTracker enforces a quota policy. Instances that are being created or started are
+// reserved, so that concurrent requests cannot together exceed a quota.
+type quotaTracker struct {
+	mu       sync.Mutex
+	policy   types.QuotaPolicy
+	reserved []*quotaReservation
+
+	// released counts the released reservations, so that a check can tell whether an instance
+	// created since it listed the instances is missing from the listing
+	released int
+}
+
+// quotaReservation is the share of the quotas held by an instance that is being created, or
+// the memory held by an existing instance that is being started.
+type quotaReservation struct {
+	dbType     types.DatabaseType
+	memory     int64
+	session    string
+	instanceID string // Set when starting an existing instance
+}
+
+// reserveQuota checks that an instance with the given options fits the quotas and reserves its
+// share until the returned release function is called.
+func (m *UnifiedManager) reserveQuota(ctx context.Context, opts types.CreateInstanceOptions) (func(), error) {
+	q := m.quotas
+	if q.policy.IsZero() {
+		return func() {}, nil
+	}
+
+	engine, _ := types.LookupEngine(opts.Type)
+	limits, err := m.resources.Resolve(opts, engine.MemoryLimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %w", err)
+	}
+	reservation := &quotaReservation{
+		dbType:  opts.Type,
+		memory:  limits.Memory,
+		session: types.SessionFromContext(ctx),
+	}
+
+	return m.reserve(ctx, reservation, func(usage types.QuotaUsage) error {
+		return q.policy.Check(usage, opts.Type, reservation.memory, reservation.session != "")
+	})
+}
+
+// reserveStartQuota checks that starting an instance fits the memory quota and reserves its
+// memory until the returned release function is called. Running instances already count
+// towards the quota.
+func (m *UnifiedManager) reserveStartQuota(ctx context.Context, instance *types.DatabaseInstance) (func(), error) {
+	q := m.quotas
+	if q.policy.MaxTotalMemory == 0 || isRunningStatus(instance.Status) {
+		return func() {}, nil
+	}
+
+	reservation := &quotaReservation{
+		dbType:     instance.Type,
+		memory:     instanceMemory(instance),
+		instanceID: instance.ID,
+	}
+
+	return m.reserve(ctx, reservation, func(usage types.QuotaUsage) error {
+		return q.policy.CheckMemory(usage, reservation.memory)
+	})
+}
+
+// reserve adds a reservation if check accepts the usage of the existing instances and the
+// other reservations. The instances are listed without holding the tracker's lock, since
+// listing waits for the engine managers; the listing is repeated if a reservation was
+// released meanwhile, as its instance may be missing from it.
+func (m *UnifiedManager) reserve(ctx context.Context, reservation *quotaReservation, check func(types.QuotaUsage) error) (func(), error) {
+	q := m.quotas
+
+	for {
+		q.mu.Lock()
+		released := q.released
+		q.mu.Unlock()
+
+		instances, err := m.listAllInstances(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances for quota check: %w", err)
+		}
+
+		q.mu.Lock()
+		if q.released != released {
+			q.mu.Unlock()
+			continue
+		}
+
+		usage := m.quotaUsage(instances, q.reserved, reservation.session)
+		if err := check(usage); err != nil {
+			q.mu.Unlock()
+			slog.Warn("Instance quota exceeded", "type", reservation.dbType, "session", reservation.session, "error", err)
+			return nil, err
+		}
+
+		q.reserved = append(q.reserved, reservation)
+		q.mu.Unlock()
+
+		return func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			q.reserved = slices.DeleteFunc(q.reserved, func(r *quotaReservation) bool { return r == reservation })
+			q.released++
+		}, nil
+	}
+}
+
+// quotaUsage sums up the quota usage of the existing instances and the reservations. Only the
+// instances of this manager's owner count towards a session's usage.
+func (m *UnifiedManager) quotaUsage(instances []*types.DatabaseInstance, reserved []*quotaReservation, session string) types.QuotaUsage {
+	usage := types.QuotaUsage{InstancesByType: make(map[types.DatabaseType]int)}
+	running := make(map[string]bool)
+
+	for _, instance := range instances {
+		usage.Instances++
+		usage.InstancesByType[instance.Type]++
+		if isRunningStatus(instance.Status) {
+			usage.Memory += instanceMemory(instance)
+			running[instance.ID] = true
+		}
+		if session != "" && instance.Session == session && instance.Owner == m.ownerID {
+			usage.SessionInstances++
+		}
+	}
+
+	for _, r := range reserved {
+		if r.instanceID != "" {
+			// Started instances already count, and their memory once they are listed as running
+			if !running[r.instanceID] {
+				usage.Memory += r.memory
+			}
+			continue
+		}
+		usage.Instances++
+		usage.InstancesByType[r.dbType]++
+		usage.Memory += r.memory
+		if session != "" && r.session == session {
+			usage.SessionInstances++
+		}
+	}
+
+	return usage
+}
+
+// instanceMemory returns the memory limit of an instance. Instances without recorded limits
+// were created with the default.
+func instanceMemory(instance *types.DatabaseInstance) int64 {
+	if instance.Resources != nil {
+		return instance.Resources.Memory
+	}
+	return types.DefaultMemoryLimit
+}
+
+// isRunningStatus reports whether an instance with the given status has a running container.
+func isRunningStatus(status string) bool {
+	switch status {
+	case "running", "starting", "unhealthy", "paused", "restarting":
+		return true
+	default:
+		return false
+	}
+}
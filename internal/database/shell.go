@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// ShellCommand returns the commands that open an interactive client session on an instance,
+// either with a client installed on the host or with the client inside the container.
+func (m *GenericManager) ShellCommand(ctx context.Context, id string) (*types.ShellCommand, error) {
+	if len(m.config.shellTemplates) == 0 {
+		return nil, fmt.Errorf("%s instances do not support interactive shells", m.config.Type)
+	}
+
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.BuildShellCommand(instance)
+}
+
+// BuildShellCommand renders the engine's client commands for an instance.
+func (m *GenericManager) BuildShellCommand(instance *types.DatabaseInstance) (*types.ShellCommand, error) {
+	if len(m.config.shellTemplates) == 0 {
+		return nil, fmt.Errorf("%s instances do not support interactive shells", m.config.Type)
+	}
+
+	data := TemplateData{
+		Database: instance.Database,
+		Username: instance.Username,
+		Password: instance.Password,
+		Port:     instance.Port,
+	}
+
+	shell := &types.ShellCommand{Instance: instance}
+
+	var err error
+	shell.Container, err = m.renderCommand(m.config.shellTemplates, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute shell command template: %w", err)
+	}
+
+	if len(m.config.hostTemplates) > 0 {
+		shell.Host, err = m.renderCommand(m.config.hostTemplates, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute host shell command template: %w", err)
+		}
+		shell.HostEnv, err = m.clientEnvironment(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shell, nil
+}
+
+// ShellCommand returns the commands that open an interactive client session on an instance.
+func (m *UnifiedManager) ShellCommand(ctx context.Context, id string) (*types.ShellCommand, error) {
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.ShellCommand(ctx, instance.ID)
+}
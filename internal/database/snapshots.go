@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// SnapshotInstance takes a logical snapshot of an instance's database and stores it in the
+// snapshot store. The name is an optional label.
+func (m *UnifiedManager) SnapshotInstance(ctx context.Context, id, name string) (*types.Snapshot, error) {
+	if m.snapshots == nil {
+		return nil, fmt.Errorf("snapshot store is not configured")
+	}
+
+	manager, instance, err := m.instanceManager(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &types.Snapshot{
+		ID:         types.GenerateInstanceID(),
+		Name:       name,
+		InstanceID: instance.ID,
+		Type:       instance.Type,
+		Version:    instance.Version,
+		Database:   instance.Database,
+		Username:   instance.Username,
+		Method:     types.SnapshotMethodLogical,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if principal := types.PrincipalFromContext(ctx); principal != nil {
+		snapshot.Principal = principal.Name
+	}
+
+	err = m.snapshots.Create(snapshot, func(w io.Writer) error {
+		return manager.DumpDatabase(ctx, instance.ID, w)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot instance %s: %w", instance.ID, err)
+	}
+
+	slog.Info("Database instance snapshot created", "instance_id", instance.ID, "snapshot_id", snapshot.ID, "size", snapshot.Size)
+
+	return snapshot, nil
+}
+
+// RestoreSnapshot restores a snapshot into the instance targetID, replacing the objects
+// contained in the snapshot. If targetID is empty, a new instance with the snapshot's type,
+// version, database and username is created first and removed again if the restore fails.
+func (m *UnifiedManager) RestoreSnapshot(ctx context.Context, snapshotID, targetID string) (*types.DatabaseInstance, error) {
+	if m.snapshots == nil {
+		return nil, fmt.Errorf("snapshot store is not configured")
+	}
+
+	snapshot, err := m.getSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	var instance *types.DatabaseInstance
+	created := false
+	if targetID == "" {
+		instance, err = m.CreateInstance(ctx, types.CreateInstanceOptions{
+			Type:     snapshot.Type,
+			Version:  snapshot.Version,
+			Database: snapshot.Database,
+			Username: snapshot.Username,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create instance for snapshot %s: %w", snapshot.ID, err)
+		}
+		created = true
+	} else {
+		instance, err = m.GetInstance(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if instance.Type != snapshot.Type {
+			return nil, fmt.Errorf("cannot restore %s snapshot %s into %s instance %s", snapshot.Type, snapshot.ID, instance.Type, instance.ID)
+		}
+	}
+
+	if err := m.restoreSnapshotInto(ctx, snapshot, instance); err != nil {
+		if created {
+			if dropErr := m.DropInstance(ctx, instance.ID); dropErr != nil {
+				slog.Warn("Failed to remove instance after failed restore", "instance_id", instance.ID, "error", dropErr)
+			}
+		}
+		return nil, err
+	}
+
+	slog.Info("Snapshot restored", "snapshot_id", snapshot.ID, "instance_id", instance.ID)
+
+	return instance, nil
+}
+
+// ListSnapshots returns the stored snapshots that the principal in the context may access,
+// oldest first.
+func (m *UnifiedManager) ListSnapshots(ctx context.Context) ([]*types.Snapshot, error) {
+	if m.snapshots == nil {
+		return nil, fmt.Errorf("snapshot store is not configured")
+	}
+
+	snapshots, err := m.snapshots.List()
+	if err != nil {
+		return nil, err
+	}
+
+	principal := types.PrincipalFromContext(ctx)
+	accessible := make([]*types.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if principal.CanAccess(snapshot.Principal) {
+			accessible = append(accessible, snapshot)
+		}
+	}
+	return accessible, nil
+}
+
+// DeleteSnapshot removes a stored snapshot and returns its metadata.
+func (m *UnifiedManager) DeleteSnapshot(ctx context.Context, id string) (*types.Snapshot, error) {
+	if m.snapshots == nil {
+		return nil, fmt.Errorf("snapshot store is not configured")
+	}
+
+	snapshot, err := m.getSnapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return m.snapshots.Delete(snapshot.ID)
+}
+
+// getSnapshot returns a snapshot by ID or unique ID prefix. Snapshots that the principal in
+// the context may not access are reported as not found.
+func (m *UnifiedManager) getSnapshot(ctx context.Context, id string) (*types.Snapshot, error) {
+	snapshots, err := m.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.MatchSnapshot(snapshots, id)
+}
+
+// restoreSnapshotInto streams a snapshot's data into an instance.
+func (m *UnifiedManager) restoreSnapshotInto(ctx context.Context, snapshot *types.Snapshot, instance *types.DatabaseInstance) error {
+	manager, exists := m.managers[instance.Type]
+	if !exists {
+		return fmt.Errorf("unsupported database type: %s", instance.Type)
+	}
+
+	data, err := m.snapshots.Open(snapshot.ID)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+
+	if err := manager.RestoreDatabase(ctx, instance.ID, data); err != nil {
+		return fmt.Errorf("failed to restore snapshot %s: %w", snapshot.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/volume"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// DetachInstance removes a persistent instance's container but keeps its data volume,
+// so the instance can later be recreated with AttachVolume.
+func (m *GenericManager) DetachInstance(ctx context.Context, id string) (*types.VolumeInfo, error) {
+	instance, err := m.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if instance.Volume == "" {
+		return nil, fmt.Errorf("%s instance %s is not persistent", m.config.Type, instance.ID)
+	}
+
+	slog.Info("Detaching database instance", "type", m.config.Type, "instance_id", instance.ID, "volume", instance.Volume)
+
+	// Stop and remove container; the volume and stored credentials are kept for reattaching
+	if err := m.docker.StopContainer(ctx, instance.ContainerID); err != nil {
+		slog.Warn("Failed to stop container", "type", m.config.Type, "instance_id", instance.ID, "error", err)
+	}
+
+	if err := m.docker.RemoveContainer(ctx, instance.ContainerID); err != nil {
+		return nil, fmt.Errorf("failed to remove %s container: %w", m.config.Type, err)
+	}
+
+	// Release port
+	m.releasePort(instance.Port)
+
+	// Remove from in-memory instances
+	m.mu.Lock()
+	delete(m.instances, instance.ID)
+	m.mu.Unlock()
+
+	slog.Info("Database instance detached successfully", "type", m.config.Type, "instance_id", instance.ID, "volume", instance.Volume)
+
+	return m.inspectVolume(ctx, instance.Volume)
+}
+
+// AttachVolume recreates an instance from a detached data volume. The instance keeps its
+// original ID, database, username and version, and gets a freshly allocated port.
+func (m *GenericManager) AttachVolume(ctx context.Context, name string) (*types.DatabaseInstance, error) {
+	info, err := m.inspectVolume(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsAttached() {
+		return nil, fmt.Errorf("volume %s is already attached to container %s", name, info.ContainerID)
+	}
+
+	// The data directory is already initialized, so the password must be the one it was created with
+	password := m.lookupPassword(info.InstanceID)
+	if password == "" {
+		slog.Warn("Password for volume is not known, the DSN will be incomplete", "type", m.config.Type, "volume", name)
+	}
+
+	// The instance belongs to the principal that created the volume, even if an admin attaches it
+	var principal *types.Principal
+	if info.Principal != "" {
+		principal = &types.Principal{Name: info.Principal}
+	}
+	ctx = types.ContextWithPrincipal(ctx, principal)
+
+	slog.Info("Attaching data volume", "type", m.config.Type, "instance_id", info.InstanceID, "volume", name)
+
+	port, err := m.docker.AllocatePort(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate port: %w", err)
+	}
+
+	opts := types.CreateInstanceOptions{
+		Type:       m.config.Type,
+		Version:    info.Version,
+		Database:   info.Database,
+		Username:   info.Username,
+		Password:   password,
+		Persistent: true,
+	}
+
+	instance, err := m.createContainer(ctx, info.InstanceID, opts, port, name)
+	if err != nil {
+		m.docker.ReleasePort(port)
+		return nil, fmt.Errorf("failed to create %s container: %w", m.config.Type, err)
+	}
+
+	m.mu.Lock()
+	m.instances[instance.ID] = instance
+	m.mu.Unlock()
+
+	slog.Info("Data volume attached successfully", "type", m.config.Type, "instance_id", instance.ID, "port", port)
+
+	return instance, nil
+}
+
+// ListVolumes returns all data volumes of this type.
+func (m *GenericManager) ListVolumes(ctx context.Context) ([]*types.VolumeInfo, error) {
+	volumes, err := m.docker.ListVolumesByType(ctx, m.config.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s volumes: %w", m.config.Type, err)
+	}
+
+	attached, err := m.attachedVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*types.VolumeInfo, 0, len(volumes))
+	for _, vol := range volumes {
+		info := volumeInfoFromLabels(vol)
+		info.ContainerID = attached[vol.Name]
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// DeleteVolume removes a detached data volume and all its data.
+func (m *GenericManager) DeleteVolume(ctx context.Context, name string) error {
+	info, err := m.inspectVolume(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if info.IsAttached() {
+		return fmt.Errorf("volume %s is in use by instance %s; detach or drop the instance first", name, info.InstanceID)
+	}
+
+	if err := m.docker.RemoveVolume(ctx, name); err != nil {
+		return fmt.Errorf("failed to remove %s data volume: %w", m.config.Type, err)
+	}
+
+	// The instance can no longer be reattached, so its credentials are no longer needed
+	if m.credentials != nil {
+		if err := m.credentials.DeletePassword(info.InstanceID); err != nil {
+			slog.Warn("Failed to delete stored password", "type", m.config.Type, "instance_id", info.InstanceID, "error", err)
+		}
+	}
+
+	slog.Info("Data volume deleted successfully", "type", m.config.Type, "volume", name)
+	return nil
+}
+
+// createVolume creates the data volume for a new persistent instance.
+func (m *GenericManager) createVolume(ctx context.Context, instanceID string, opts types.CreateInstanceOptions) (string, error) {
+	name := types.GetVolumeName(instanceID, m.config.Type)
+
+	labels := map[string]string{
+		"dev-postgres-mcp.managed":     "true",
+		"dev-postgres-mcp.type":        string(m.config.Type),
+		"dev-postgres-mcp.instance-id": instanceID,
+		"dev-postgres-mcp.database":    opts.Database,
+		"dev-postgres-mcp.username":    opts.Username,
+		"dev-postgres-mcp.version":     opts.Version,
+		"dev-postgres-mcp.created-at":  time.Now().UTC().Format(time.RFC3339),
+	}
+	if principal := principalName(ctx); principal != "" {
+		labels["dev-postgres-mcp.principal"] = principal
+	}
+
+	_, err := m.docker.CreateVolume(ctx, name, labels)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s data volume: %w", m.config.Type, err)
+	}
+
+	return name, nil
+}
+
+// inspectVolume returns information about a managed data volume of this type.
+func (m *GenericManager) inspectVolume(ctx context.Context, name string) (*types.VolumeInfo, error) {
+	vol, err := m.docker.InspectVolume(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if vol.Labels["dev-postgres-mcp.managed"] != "true" || vol.Labels["dev-postgres-mcp.type"] != string(m.config.Type) {
+		return nil, fmt.Errorf("volume %s is not a managed %s data volume", name, m.config.Type)
+	}
+
+	attached, err := m.attachedVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := volumeInfoFromLabels(vol)
+	info.ContainerID = attached[name]
+	return info, nil
+}
+
+// attachedVolumes maps volume names to the IDs of the managed containers using them.
+func (m *GenericManager) attachedVolumes(ctx context.Context) (map[string]string, error) {
+	containers, err := m.listContainers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s containers: %w", m.config.Type, err)
+	}
+
+	attached := make(map[string]string)
+	for _, cont := range containers {
+		if name := cont.Labels["dev-postgres-mcp.volume"]; name != "" {
+			attached[name] = cont.ID
+		}
+	}
+
+	return attached, nil
+}
+
+// volumeInfoFromLabels extracts volume information from a volume's labels.
+func volumeInfoFromLabels(vol *volume.Volume) *types.VolumeInfo {
+	createdAt, _ := time.Parse(time.RFC3339, vol.Labels["dev-postgres-mcp.created-at"])
+
+	return &types.VolumeInfo{
+		Name:       vol.Name,
+		InstanceID: vol.Labels["dev-postgres-mcp.instance-id"],
+		Type:       types.DatabaseType(vol.Labels["dev-postgres-mcp.type"]),
+		Version:    vol.Labels["dev-postgres-mcp.version"],
+		Database:   vol.Labels["dev-postgres-mcp.database"],
+		Username:   vol.Labels["dev-postgres-mcp.username"],
+		Principal:  vol.Labels["dev-postgres-mcp.principal"],
+		CreatedAt:  createdAt,
+	}
+}
+
+// principalName returns the name of the principal in the context, or "" for local access.
+func principalName(ctx context.Context) string {
+	if principal := types.PrincipalFromContext(ctx); principal != nil {
+		return principal.Name
+	}
+	return ""
+}
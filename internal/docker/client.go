@@ -2,13 +2,23 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 	imagetypes "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-units"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
 )
 
 // Client wraps the Docker client with additional functionality.
@@ -55,27 +65,121 @@ func (c *Client) PullImage(ctx context.Context, imageName string) error {
 	}
 
 	slog.Info("Pulling image", "image", imageName)
+	types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhasePull, Message: fmt.Sprintf("Pulling %s", imageName)})
 	reader, err := c.cli.ImagePull(ctx, imageName, imagetypes.PullOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to pull image %s: %w", imageName, err)
 	}
 	defer reader.Close()
 
-	// Read the response to ensure the pull completes
-	// In a real implementation, you might want to parse the JSON response
-	// and show progress, but for now we'll just read it all
-	buf := make([]byte, 1024)
+	// The pull completes when the stream ends; its messages report the progress of each layer
+	pull := newPullProgress(imageName)
+	decoder := json.NewDecoder(reader)
 	for {
-		_, err := reader.Read(buf)
-		if err != nil {
+		var message pullMessage
+		if err := decoder.Decode(&message); err == io.EOF {
 			break
+		} else if err != nil {
+			return fmt.Errorf("failed to pull image %s: %w", imageName, err)
+		}
+		if message.Error != nil {
+			return fmt.Errorf("failed to pull image %s: %s", imageName, message.Error.Message)
 		}
+		pull.update(ctx, message)
 	}
 
 	slog.Info("Image pulled successfully", "image", imageName)
 	return nil
 }
 
+// pullMessage is a message of an image pull stream.
+type pullMessage struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Progress struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// pullLayer is the state of a layer being pulled.
+type pullLayer struct {
+	downloaded int64
+	size       int64
+	complete   bool
+}
+
+// pullProgress tracks the layers of an image pull and reports its progress.
+type pullProgress struct {
+	image    string
+	layers   map[string]*pullLayer
+	order    []string
+	reported time.Time
+}
+
+// newPullProgress creates a pull progress tracker for an image.
+func newPullProgress(image string) *pullProgress {
+	return &pullProgress{image: image, layers: make(map[string]*pullLayer)}
+}
+
+// update applies a pull message and reports the progress, at most a few times per second
+// unless a layer completed.
+func (p *pullProgress) update(ctx context.Context, message pullMessage) {
+	// Messages without a layer ID are about the image as a whole, e.g. its digest, and the
+	// "Pulling from" message carries the image's tag as its ID
+	if message.ID == "" || strings.HasPrefix(message.Status, "Pulling from") {
+		return
+	}
+
+	layer, ok := p.layers[message.ID]
+	if !ok {
+		layer = &pullLayer{}
+		p.layers[message.ID] = layer
+		p.order = append(p.order, message.ID)
+	}
+
+	completed := false
+	switch message.Status {
+	case "Downloading":
+		layer.downloaded = message.Progress.Current
+		layer.size = message.Progress.Total
+	case "Download complete", "Verifying Checksum":
+		layer.downloaded = layer.size
+	case "Pull complete", "Already exists":
+		layer.downloaded = layer.size
+		completed = !layer.complete
+		layer.complete = true
+	}
+
+	if !completed && time.Since(p.reported) < 250*time.Millisecond {
+		return
+	}
+	p.reported = time.Now()
+
+	var downloaded, size int64
+	complete := 0
+	for _, id := range p.order {
+		layer := p.layers[id]
+		downloaded += layer.downloaded
+		size += layer.size
+		if layer.complete {
+			complete++
+		}
+	}
+
+	// Downloading and extracting the layers count for half of the pull each
+	fraction := float64(complete) / float64(len(p.order)) / 2
+	status := fmt.Sprintf("Pulling %s: %d/%d layers", p.image, complete, len(p.order))
+	if size > 0 {
+		fraction += float64(downloaded) / float64(size) / 2
+		status += fmt.Sprintf(", %s/%s", units.HumanSize(float64(downloaded)), units.HumanSize(float64(size)))
+	}
+	types.ReportProgress(ctx, types.Progress{Phase: types.ProgressPhasePull, Fraction: fraction, Message: status})
+}
+
 // CreateContainer creates a new container with the given configuration.
 func (c *Client) CreateContainer(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, containerName string) (string, error) {
 	slog.Info("Creating container", "name", containerName, "image", config.Image)
@@ -159,28 +263,42 @@ func (c *Client) ListContainers(ctx context.Context, options container.ListOptio
 	return containers, nil
 }
 
-// ContainerLogs retrieves logs from a container.
+// ContainerLogs retrieves logs from a container, with standard output and standard error
+// interleaved in the order they were written.
 func (c *Client) ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (string, error) {
+	var logs bytes.Buffer
+	if err := c.StreamContainerLogs(ctx, containerID, options, &logs, &logs); err != nil {
+		return logs.String(), err
+	}
+
+	return logs.String(), nil
+}
+
+// StreamContainerLogs copies logs from a container to stdout and stderr, demultiplexing
+// Docker's log stream. With options.Follow it returns once the container stops or ctx is
+// cancelled. Nil writers discard the corresponding stream.
+func (c *Client) StreamContainerLogs(ctx context.Context, containerID string, options container.LogsOptions, stdout, stderr io.Writer) error {
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
 	reader, err := c.cli.ContainerLogs(ctx, containerID, options)
 	if err != nil {
-		return "", fmt.Errorf("failed to get logs for container %s: %w", containerID, err)
+		return fmt.Errorf("failed to get logs for container %s: %w", containerID, err)
 	}
 	defer reader.Close()
 
-	// Read all logs
-	buf := make([]byte, 4096)
-	var logs string
-	for {
-		n, err := reader.Read(buf)
-		if n > 0 {
-			logs += string(buf[:n])
-		}
-		if err != nil {
-			break
+	if _, err := stdcopy.StdCopy(stdout, stderr, reader); err != nil {
+		if ctx.Err() != nil {
+			return nil
 		}
+		return fmt.Errorf("failed to read logs for container %s: %w", containerID, err)
 	}
 
-	return logs, nil
+	return nil
 }
 
 // IsContainerRunning checks if a container is currently running.
@@ -192,3 +310,51 @@ func (c *Client) IsContainerRunning(ctx context.Context, containerID string) (bo
 
 	return inspect.State.Running, nil
 }
+
+// CreateVolume creates a named volume with the given labels.
+func (c *Client) CreateVolume(ctx context.Context, name string, labels map[string]string) (*volume.Volume, error) {
+	slog.Info("Creating volume", "name", name)
+
+	vol, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Labels: labels,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volume %s: %w", name, err)
+	}
+
+	slog.Info("Volume created successfully", "name", name)
+	return &vol, nil
+}
+
+// RemoveVolume removes a named volume. The volume must not be in use by a container.
+func (c *Client) RemoveVolume(ctx context.Context, name string) error {
+	slog.Info("Removing volume", "name", name)
+
+	if err := c.cli.VolumeRemove(ctx, name, false); err != nil {
+		return fmt.Errorf("failed to remove volume %s: %w", name, err)
+	}
+
+	slog.Info("Volume removed successfully", "name", name)
+	return nil
+}
+
+// InspectVolume returns detailed information about a volume.
+func (c *Client) InspectVolume(ctx context.Context, name string) (*volume.Volume, error) {
+	vol, err := c.cli.VolumeInspect(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect volume %s: %w", name, err)
+	}
+
+	return &vol, nil
+}
+
+// ListVolumes lists volumes with optional filters.
+func (c *Client) ListVolumes(ctx context.Context, options volume.ListOptions) ([]*volume.Volume, error) {
+	resp, err := c.cli.VolumeList(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	return resp.Volumes, nil
+}
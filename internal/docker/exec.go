@@ -0,0 +1,183 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// ErrExecTimeout is returned when a command does not finish within its timeout.
+var ErrExecTimeout = errors.New("command timed out")
+
+// Exec runs a command inside a running container and returns its exit code.
+// An error is returned only if the command could not be run, its output could not be
+// read, or it did not finish within opts.Timeout (ErrExecTimeout).
+func (c *Client) Exec(ctx context.Context, containerID string, opts types.ExecOptions) (int, error) {
+	if len(opts.Cmd) == 0 {
+		return -1, fmt.Errorf("exec command must not be empty")
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	execID, err := c.CreateExec(ctx, containerID, opts)
+	if err != nil {
+		return -1, err
+	}
+
+	attached, err := c.cli.ContainerExecAttach(ctx, execID, container.ExecAttachOptions{
+		Tty:         opts.Tty,
+		ConsoleSize: opts.ConsoleSize,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("failed to attach to exec in container %s: %w", containerID, err)
+	}
+	defer attached.Close()
+
+	// Closing the connection unblocks the output copy when the context ends
+	stop := context.AfterFunc(ctx, func() { attached.Close() })
+	defer stop()
+
+	if opts.Tty && opts.Resize != nil {
+		go c.forwardResizes(ctx, execID, opts.Resize)
+	}
+
+	stdinErr := make(chan error, 1)
+	if opts.Stdin != nil {
+		go func() {
+			_, err := io.Copy(attached.Conn, opts.Stdin)
+			// Signal end of input so the command can finish
+			if closeErr := attached.CloseWrite(); err == nil {
+				err = closeErr
+			}
+			stdinErr <- err
+		}()
+	} else {
+		stdinErr <- nil
+	}
+
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	// Without a TTY, stdout and stderr are multiplexed on the stream
+	var copyErr error
+	if opts.Tty {
+		_, copyErr = io.Copy(stdout, attached.Reader)
+	} else {
+		_, copyErr = stdcopy.StdCopy(stdout, stderr, attached.Reader)
+	}
+	if ctx.Err() != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && opts.Timeout > 0 {
+			return -1, fmt.Errorf("%w after %s", ErrExecTimeout, opts.Timeout)
+		}
+		return -1, ctx.Err()
+	}
+	if copyErr != nil {
+		return -1, fmt.Errorf("failed to read exec output from container %s: %w", containerID, copyErr)
+	}
+
+	exitCode, err := c.waitExec(ctx, execID)
+	if err != nil {
+		return -1, err
+	}
+
+	// A failed command may stop reading its input early; its exit code is the more useful result.
+	// Interactive input never ends on its own, so it is not waited for either.
+	if exitCode != 0 || opts.Tty {
+		return exitCode, nil
+	}
+
+	if err := <-stdinErr; err != nil {
+		return -1, fmt.Errorf("failed to write exec input to container %s: %w", containerID, err)
+	}
+
+	return exitCode, nil
+}
+
+// CreateExec creates an exec instance in a running container without starting it and
+// returns its ID. Standard output and error are always attached.
+func (c *Client) CreateExec(ctx context.Context, containerID string, opts types.ExecOptions) (string, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          opts.Cmd,
+		Env:          opts.Env,
+		User:         opts.User,
+		WorkingDir:   opts.WorkingDir,
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          opts.Tty,
+		ConsoleSize:  opts.ConsoleSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create exec in container %s: %w", containerID, err)
+	}
+
+	return created.ID, nil
+}
+
+// forwardResizes applies terminal size changes to a TTY exec until the context ends.
+func (c *Client) forwardResizes(ctx context.Context, execID string, sizes <-chan [2]uint) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-sizes:
+			if !ok {
+				return
+			}
+			err := c.cli.ContainerExecResize(ctx, execID, container.ResizeOptions{Height: size[0], Width: size[1]})
+			if err != nil {
+				slog.Debug("Failed to resize exec terminal", "exec_id", execID, "error", err)
+			}
+		}
+	}
+}
+
+// InspectExec reports whether an exec instance is still running and, once it has finished, its exit code.
+func (c *Client) InspectExec(ctx context.Context, execID string) (running bool, exitCode int, err error) {
+	inspect, err := c.cli.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return false, -1, fmt.Errorf("failed to inspect exec %s: %w", execID, err)
+	}
+
+	return inspect.Running, inspect.ExitCode, nil
+}
+
+// waitExec returns the exit code of an exec instance whose output stream has ended.
+// The daemon may report the exec as running for a short moment after that.
+func (c *Client) waitExec(ctx context.Context, execID string) (int, error) {
+	for attempt := 0; ; attempt++ {
+		running, exitCode, err := c.InspectExec(ctx, execID)
+		if err != nil {
+			return -1, err
+		}
+		if !running {
+			return exitCode, nil
+		}
+		if attempt >= 20 {
+			return -1, fmt.Errorf("exec %s is still running after its output ended", execID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return -1, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
@@ -7,7 +7,8 @@ import (
 	"log/slog"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL driver
+	_ "github.com/go-sql-driver/mysql" // MySQL and MariaDB driver
+	_ "github.com/lib/pq"              // PostgreSQL driver
 )
 
 // HealthStatus represents the health status of a container or service.
@@ -34,7 +35,7 @@ type HealthCheck struct {
 	Duration  time.Duration `json:"duration"`
 }
 
-// HealthChecker provides health checking functionality for PostgreSQL containers.
+// HealthChecker provides health checking functionality for database containers.
 type HealthChecker struct {
 	client *Client
 }
@@ -110,17 +111,18 @@ func (hc *HealthChecker) CheckContainerHealth(ctx context.Context, containerID s
 	}, nil
 }
 
-// CheckPostgreSQLConnection checks if PostgreSQL is accessible by attempting a connection.
-func (hc *HealthChecker) CheckPostgreSQLConnection(ctx context.Context, dsn string) (*HealthCheck, error) {
+// CheckConnection checks if a database is accessible by connecting with the given
+// database/sql driver and running a test query. The returned duration is the connection latency.
+func (hc *HealthChecker) CheckConnection(ctx context.Context, driverName, dsn string) (*HealthCheck, error) {
 	start := time.Now()
 
-	slog.Debug("Checking PostgreSQL connection", "dsn", dsn)
+	slog.Debug("Checking database connection", "driver", driverName)
 
 	// Create a context with timeout for the connection attempt
 	connCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
 		return &HealthCheck{
 			Status:    HealthStatusUnhealthy,
@@ -169,14 +171,26 @@ func (hc *HealthChecker) CheckPostgreSQLConnection(ctx context.Context, dsn stri
 
 	return &HealthCheck{
 		Status:    HealthStatusHealthy,
-		Message:   "PostgreSQL connection successful",
+		Message:   "Database connection successful",
 		Timestamp: time.Now(),
 		Duration:  time.Since(start),
 	}, nil
 }
 
-// CheckPostgreSQLInstance performs a comprehensive health check on a PostgreSQL instance.
-func (hc *HealthChecker) CheckPostgreSQLInstance(ctx context.Context, containerID, dsn string) (*HealthCheck, error) {
+// CheckPostgreSQLConnection checks if PostgreSQL is accessible by attempting a connection.
+func (hc *HealthChecker) CheckPostgreSQLConnection(ctx context.Context, dsn string) (*HealthCheck, error) {
+	return hc.CheckConnection(ctx, "postgres", dsn)
+}
+
+// CheckMySQLConnection checks if MySQL or MariaDB is accessible by attempting a connection.
+// The DSN uses the go-sql-driver/mysql format.
+func (hc *HealthChecker) CheckMySQLConnection(ctx context.Context, dsn string) (*HealthCheck, error) {
+	return hc.CheckConnection(ctx, "mysql", dsn)
+}
+
+// CheckInstance performs a comprehensive health check on a database instance: the container
+// must be healthy and accept a connection with the given driver and DSN.
+func (hc *HealthChecker) CheckInstance(ctx context.Context, containerID, driverName, dsn string) (*HealthCheck, error) {
 	// First check container health
 	containerHealth, err := hc.CheckContainerHealth(ctx, containerID)
 	if err != nil {
@@ -188,8 +202,13 @@ func (hc *HealthChecker) CheckPostgreSQLInstance(ctx context.Context, containerI
 		return containerHealth, nil
 	}
 
-	// If container is healthy, check PostgreSQL connection
-	return hc.CheckPostgreSQLConnection(ctx, dsn)
+	// If container is healthy, check the database connection
+	return hc.CheckConnection(ctx, driverName, dsn)
+}
+
+// CheckPostgreSQLInstance performs a comprehensive health check on a PostgreSQL instance.
+func (hc *HealthChecker) CheckPostgreSQLInstance(ctx context.Context, containerID, dsn string) (*HealthCheck, error) {
+	return hc.CheckInstance(ctx, containerID, "postgres", dsn)
 }
 
 // WaitForHealthy waits for a PostgreSQL instance to become healthy.
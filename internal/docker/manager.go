@@ -3,6 +3,7 @@ package docker
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
 	"sync"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/go-connections/nat"
 
 	"github.com/stokaro/dev-postgres-mcp/pkg/types"
@@ -53,6 +56,15 @@ func (pm *PortManager) AllocatePort(_ context.Context) (int, error) {
 	return 0, fmt.Errorf("no available ports in range %d-%d", pm.startPort, pm.endPort)
 }
 
+// ReservePort marks a port as allocated without checking its availability.
+// It is used for ports already bound by existing containers, including stopped ones.
+func (pm *PortManager) ReservePort(port int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	pm.allocated[port] = true
+}
+
 // ReleasePort releases a previously allocated port.
 func (pm *PortManager) ReleasePort(port int) {
 	pm.mu.Lock()
@@ -97,8 +109,9 @@ func (pm *PortManager) isPortAvailable(port int) bool {
 
 // Manager combines Docker client and port management functionality.
 type Manager struct {
-	client      *Client
-	portManager *PortManager
+	client        *Client
+	portManager   *PortManager
+	healthChecker *HealthChecker
 }
 
 // NewManager creates a new Docker manager with the specified port range.
@@ -111,11 +124,17 @@ func NewManager(startPort, endPort int) (*Manager, error) {
 	portManager := NewPortManager(startPort, endPort)
 
 	return &Manager{
-		client:      client,
-		portManager: portManager,
+		client:        client,
+		portManager:   portManager,
+		healthChecker: NewHealthChecker(client),
 	}, nil
 }
 
+// HealthChecker returns the health checker for the managed containers.
+func (m *Manager) HealthChecker() *HealthChecker {
+	return m.healthChecker
+}
+
 // Close closes the Docker manager and releases resources.
 func (m *Manager) Close() error {
 	return m.client.Close()
@@ -131,6 +150,11 @@ func (m *Manager) AllocatePort(ctx context.Context) (int, error) {
 	return m.portManager.AllocatePort(ctx)
 }
 
+// ReservePort marks a port as allocated.
+func (m *Manager) ReservePort(port int) {
+	m.portManager.ReservePort(port)
+}
+
 // ReleasePort releases a previously allocated port.
 func (m *Manager) ReleasePort(port int) {
 	m.portManager.ReleasePort(port)
@@ -146,10 +170,16 @@ type GenericContainerConfig struct {
 	Image         string
 	ContainerName string
 	Environment   []string
+	Command       []string // Overrides the image's default command if not empty
 	Port          int
 	ContainerPort string
 	HealthCheck   []string
+	Memory        int64   // Memory limit in bytes (512 MiB if zero)
+	CPUs          float64 // CPU limit (1 CPU if zero)
+	ShmSize       int64   // Size of /dev/shm in bytes (Docker's default if zero)
 	Labels        map[string]string
+	Volumes       map[string]string // Named volume -> mount path inside the container
+	Tmpfs         []string          // Paths inside the container to mount as tmpfs
 }
 
 // CreateGenericContainer creates a generic database container.
@@ -163,6 +193,7 @@ func (m *Manager) CreateGenericContainer(ctx context.Context, config GenericCont
 	containerConfig := &container.Config{
 		Image:  config.Image,
 		Env:    config.Environment,
+		Cmd:    config.Command,
 		Labels: config.Labels,
 		ExposedPorts: nat.PortSet{
 			nat.Port(config.ContainerPort): struct{}{},
@@ -176,6 +207,15 @@ func (m *Manager) CreateGenericContainer(ctx context.Context, config GenericCont
 		},
 	}
 
+	memory := config.Memory
+	if memory == 0 {
+		memory = 512 * 1024 * 1024 // 512MB
+	}
+	cpus := config.CPUs
+	if cpus == 0 {
+		cpus = 1
+	}
+
 	hostConfig := &container.HostConfig{
 		PortBindings: nat.PortMap{
 			nat.Port(config.ContainerPort): []nat.PortBinding{
@@ -190,9 +230,27 @@ func (m *Manager) CreateGenericContainer(ctx context.Context, config GenericCont
 		},
 		// Set resource limits
 		Resources: container.Resources{
-			Memory:   512 * 1024 * 1024, // 512MB
-			NanoCPUs: 1000000000,        // 1 CPU core
+			Memory:   memory,
+			NanoCPUs: int64(cpus * 1e9),
 		},
+		ShmSize: config.ShmSize,
+	}
+
+	// Mount named volumes
+	for name, target := range config.Volumes {
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:   mount.TypeVolume,
+			Source: name,
+			Target: target,
+		})
+	}
+
+	// Mount tmpfs directories
+	for _, target := range config.Tmpfs {
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:   mount.TypeTmpfs,
+			Target: target,
+		})
 	}
 
 	// Create container
@@ -250,7 +308,43 @@ func (m *Manager) ContainerLogs(ctx context.Context, containerID string, options
 	return m.client.ContainerLogs(ctx, containerID, options)
 }
 
+// StreamContainerLogs streams container logs to stdout and stderr.
+func (m *Manager) StreamContainerLogs(ctx context.Context, containerID string, options container.LogsOptions, stdout, stderr io.Writer) error {
+	return m.client.StreamContainerLogs(ctx, containerID, options, stdout, stderr)
+}
+
+// Exec runs a command inside a running container and returns its exit code.
+func (m *Manager) Exec(ctx context.Context, containerID string, opts types.ExecOptions) (int, error) {
+	return m.client.Exec(ctx, containerID, opts)
+}
+
 // PullImage pulls a Docker image.
 func (m *Manager) PullImage(ctx context.Context, image string) error {
 	return m.client.PullImage(ctx, image)
 }
+
+// CreateVolume creates a named volume.
+func (m *Manager) CreateVolume(ctx context.Context, name string, labels map[string]string) (*volume.Volume, error) {
+	return m.client.CreateVolume(ctx, name, labels)
+}
+
+// RemoveVolume removes a named volume.
+func (m *Manager) RemoveVolume(ctx context.Context, name string) error {
+	return m.client.RemoveVolume(ctx, name)
+}
+
+// InspectVolume inspects a named volume.
+func (m *Manager) InspectVolume(ctx context.Context, name string) (*volume.Volume, error) {
+	return m.client.InspectVolume(ctx, name)
+}
+
+// ListVolumesByType lists all managed volumes of a specific database type.
+func (m *Manager) ListVolumesByType(ctx context.Context, dbType types.DatabaseType) ([]*volume.Volume, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", "dev-postgres-mcp.managed=true")
+	filterArgs.Add("label", fmt.Sprintf("dev-postgres-mcp.type=%s", dbType))
+
+	return m.client.ListVolumes(ctx, volume.ListOptions{
+		Filters: filterArgs,
+	})
+}
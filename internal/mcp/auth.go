@@ -0,0 +1,176 @@
+package mcp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// AuthTokensEnv is the environment variable holding API tokens as a comma-separated list of
+// principal:token entries. Appending :admin to an entry grants the token admin scope.
+const AuthTokensEnv = "DEV_POSTGRES_MCP_AUTH_TOKENS"
+
+// MinTokenLength is the minimum length of an API token.
+const MinTokenLength = 16
+
+// APIKeyHeader is the header clients can send their token in instead of a bearer token.
+const APIKeyHeader = "X-API-Key"
+
+// principalNamePattern matches valid principal names. They are stored in container labels.
+var principalNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// APIToken maps a static API token to the principal that authenticates with it.
+type APIToken struct {
+	// Token is the secret the client sends as a bearer token or in the X-API-Key header.
+	Token string `yaml:"token"`
+
+	// Principal is the name the client's instances are labelled with.
+	Principal string `yaml:"principal"`
+
+	// Admin lets the client see and manage the instances of all principals.
+	Admin bool `yaml:"admin"`
+}
+
+// authTokensFile is the format of an API tokens file (YAML or JSON).
+type authTokensFile struct {
+	Tokens []APIToken `yaml:"tokens"`
+}
+
+// LoadAuthTokens reads API tokens from a YAML or JSON file and validates them.
+func LoadAuthTokens(path string) ([]APIToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth tokens: %w", err)
+	}
+
+	var file authTokensFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse auth tokens %s: %w", path, err)
+	}
+
+	if err := ValidateAuthTokens(file.Tokens); err != nil {
+		return nil, fmt.Errorf("invalid auth tokens in %s: %w", path, err)
+	}
+
+	return file.Tokens, nil
+}
+
+// ParseAuthTokens parses API tokens in the format of AuthTokensEnv and validates them.
+func ParseAuthTokens(value string) ([]APIToken, error) {
+	var tokens []APIToken
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "admin") {
+			return nil, fmt.Errorf("invalid auth token entry %q, expected principal:token or principal:token:admin", parts[0])
+		}
+		tokens = append(tokens, APIToken{
+			Principal: parts[0],
+			Token:     parts[1],
+			Admin:     len(parts) == 3,
+		})
+	}
+
+	if err := ValidateAuthTokens(tokens); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// ValidateAuthTokens checks that the principal names are valid and that the tokens are long
+// enough and unique.
+func ValidateAuthTokens(tokens []APIToken) error {
+	seen := make(map[string]bool, len(tokens))
+	for i, token := range tokens {
+		if !principalNamePattern.MatchString(token.Principal) {
+			return fmt.Errorf("token %d: invalid principal name %q", i+1, token.Principal)
+		}
+		if len(token.Token) < MinTokenLength {
+			return fmt.Errorf("token %d: token of principal %s must be at least %d characters", i+1, token.Principal, MinTokenLength)
+		}
+		if seen[token.Token] {
+			return fmt.Errorf("token %d: token of principal %s is used more than once", i+1, token.Principal)
+		}
+		seen[token.Token] = true
+	}
+	return nil
+}
+
+// authenticator is HTTP middleware that rejects requests without a known API token and passes
+// the token's principal to the MCP handlers in the request context.
+type authenticator struct {
+	next   http.Handler
+	tokens []authToken
+}
+
+// authToken is an API token with its hashed secret.
+type authToken struct {
+	hash      [sha256.Size]byte
+	principal *types.Principal
+}
+
+// NewAuthHandler returns middleware that requires the requests to next to carry one of the API
+// tokens, either as a bearer token or in the X-API-Key header.
+func NewAuthHandler(next http.Handler, tokens []APIToken) http.Handler {
+	a := &authenticator{next: next}
+	for _, token := range tokens {
+		a.tokens = append(a.tokens, authToken{
+			hash:      sha256.Sum256([]byte(token.Token)),
+			principal: &types.Principal{Name: token.Principal, Admin: token.Admin},
+		})
+	}
+	return a
+}
+
+// ServeHTTP authenticates the request and serves it with the token's principal.
+func (a *authenticator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	principal := a.authenticate(r)
+	if principal == nil {
+		slog.Warn("Rejected unauthenticated MCP request", "remote_addr", r.RemoteAddr, "path", r.URL.Path)
+		w.Header().Set("WWW-Authenticate", `Bearer realm="dev-postgres-mcp"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	a.next.ServeHTTP(w, r.WithContext(types.ContextWithPrincipal(r.Context(), principal)))
+}
+
+// authenticate returns the principal of the request's token, or nil if it has no known token.
+// All tokens are compared in constant time, so the response time does not reveal them.
+func (a *authenticator) authenticate(r *http.Request) *types.Principal {
+	token := r.Header.Get(APIKeyHeader)
+	if scheme, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(credentials)
+	}
+	if token == "" {
+		return nil
+	}
+
+	hash := sha256.Sum256([]byte(token))
+	var principal *types.Principal
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(hash[:], t.hash[:]) == 1 {
+			principal = t.principal
+		}
+	}
+	return principal
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// originChecker is HTTP middleware that protects the network transports from browsers: pages
+// on other sites must not be able to call the server, neither directly nor by rebinding their
+// own DNS name to the server's loopback address.
+type originChecker struct {
+	next     http.Handler
+	loopback bool
+}
+
+// NewOriginHandler returns middleware that rejects requests to next whose Origin header names
+// another host than the request, and, if the server listens on a loopback address, requests
+// whose Host header is not a loopback host. Clients that are not browsers send no Origin.
+func NewOriginHandler(next http.Handler, listenAddr string) http.Handler {
+	return &originChecker{next: next, loopback: isLoopbackAddr(listenAddr)}
+}
+
+// ServeHTTP serves the request if its Host and Origin headers are allowed.
+func (o *originChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !o.allowed(r) {
+		slog.Warn("Rejected cross-origin MCP request", "remote_addr", r.RemoteAddr, "host", r.Host, "origin", r.Header.Get("Origin"))
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	o.next.ServeHTTP(w, r)
+}
+
+// allowed reports whether the request's Host and Origin headers are allowed.
+func (o *originChecker) allowed(r *http.Request) bool {
+	host := hostname(r.Host)
+	if o.loopback && !isLoopbackHost(host) {
+		return false
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	originHost := u.Hostname()
+	return strings.EqualFold(originHost, host) || (isLoopbackHost(originHost) && isLoopbackHost(host))
+}
+
+// hostname returns the host of a host[:port] value, without brackets around IPv6 addresses.
+func hostname(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// isLoopbackHost reports whether a host name or IP address refers to this host.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
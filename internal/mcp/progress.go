@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// progressMethod is the method of progress notifications.
+const progressMethod = "notifications/progress"
+
+// progressTotal is the total of the progress notifications sent for a request.
+const progressTotal = 100
+
+// progressPhases maps the phases of creating an instance to the range of the overall progress
+// they cover. Pulling an image takes longest when it is needed at all.
+var progressPhases = map[types.ProgressPhase][2]float64{
+	types.ProgressPhasePull:   {0, 50},
+	types.ProgressPhaseCreate: {50, 55},
+	types.ProgressPhaseStart:  {55, 60},
+	types.ProgressPhaseHealth: {60, 90},
+	types.ProgressPhaseSetup:  {90, 100},
+}
+
+// progressNotifier sends the progress of a request to the client as notifications/progress.
+type progressNotifier struct {
+	mcpServer *server.MCPServer
+	ctx       context.Context
+	token     mcp.ProgressToken
+
+	mu       sync.Mutex
+	progress float64
+}
+
+// withProgress returns a context that reports the progress of the request's operations to the
+// client, if the client asked for progress notifications by sending a progress token.
+func withProgress(ctx context.Context, meta *mcp.Meta) context.Context {
+	mcpServer := server.ServerFromContext(ctx)
+	if meta == nil || meta.ProgressToken == nil || mcpServer == nil {
+		return ctx
+	}
+
+	notifier := &progressNotifier{mcpServer: mcpServer, ctx: ctx, token: meta.ProgressToken}
+	return types.ContextWithProgress(ctx, notifier.notify)
+}
+
+// notify sends a progress update. The progress is mapped to the range of its phase and never
+// decreases, as the MCP specification requires.
+func (n *progressNotifier) notify(update types.Progress) {
+	bounds, ok := progressPhases[update.Phase]
+	if !ok {
+		return
+	}
+	fraction := min(max(update.Fraction, 0), 1)
+	progress := bounds[0] + fraction*(bounds[1]-bounds[0])
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.progress = max(n.progress, progress)
+
+	err := n.mcpServer.SendNotificationToClient(n.ctx, progressMethod, map[string]any{
+		"progressToken": n.token,
+		"progress":      n.progress,
+		"total":         progressTotal,
+		"message":       update.Message,
+	})
+	if err != nil {
+		slog.Debug("Failed to send progress notification", "error", err)
+	}
+}
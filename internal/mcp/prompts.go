@@ -0,0 +1,323 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// PromptHandler handles MCP prompt requests for common database workflows. Prompts are
+// rendered with live data about the instances they refer to.
+type PromptHandler struct {
+	manager *database.UnifiedManager
+}
+
+// NewPromptHandler creates a new MCP prompt handler.
+func NewPromptHandler(manager *database.UnifiedManager) *PromptHandler {
+	return &PromptHandler{
+		manager: manager,
+	}
+}
+
+// GetPrompts returns the list of available MCP prompts.
+func (h *PromptHandler) GetPrompts() []mcp.Prompt {
+	engineTypes := types.EngineTypeNames()
+
+	return []mcp.Prompt{
+		mcp.NewPrompt("provision_test_database",
+			mcp.WithPromptDescription("Provision a throwaway database, apply a migration to it and verify the result"),
+			mcp.WithArgument("migration", mcp.ArgumentDescription("The migration to test: SQL, a file path or a description"), mcp.RequiredArgument()),
+			mcp.WithArgument("type", mcp.ArgumentDescription(fmt.Sprintf("Database type: %s (default: the source instance's type, or %s)",
+				strings.Join(engineTypes, ", "), types.DatabaseTypePostgreSQL))),
+			mcp.WithArgument("version", mcp.ArgumentDescription("Database version (default: the source instance's version, or the engine default)")),
+			mcp.WithArgument("source_instance_id", mcp.ArgumentDescription("Instance whose data the migration should be tested against; it is cloned, not modified")),
+		),
+		mcp.NewPrompt("diagnose_slow_query",
+			mcp.WithPromptDescription("Diagnose why a query is slow using the engine's query plans and the instance's schema"),
+			mcp.WithArgument("instance_id", mcp.ArgumentDescription("The instance the query runs against"), mcp.RequiredArgument()),
+			mcp.WithArgument("query", mcp.ArgumentDescription("The slow query"), mcp.RequiredArgument()),
+		),
+		mcp.NewPrompt("compare_schemas",
+			mcp.WithPromptDescription("Compare the schemas of two instances and propose a migration from one to the other"),
+			mcp.WithArgument("source_instance_id", mcp.ArgumentDescription("The instance with the current schema"), mcp.RequiredArgument()),
+			mcp.WithArgument("target_instance_id", mcp.ArgumentDescription("The instance with the desired schema"), mcp.RequiredArgument()),
+		),
+	}
+}
+
+// HandlePrompt handles an MCP prompt request.
+func (h *PromptHandler) HandlePrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	name := request.Params.Name
+	arguments := request.Params.Arguments
+	slog.Info("Handling MCP prompt request", "prompt", name, "arguments", arguments)
+
+	switch name {
+	case "provision_test_database":
+		return h.handleProvisionTestDatabase(ctx, arguments)
+	case "diagnose_slow_query":
+		return h.handleDiagnoseSlowQuery(ctx, arguments)
+	case "compare_schemas":
+		return h.handleCompareSchemas(ctx, arguments)
+	default:
+		return nil, fmt.Errorf("unknown prompt: %s", name)
+	}
+}
+
+// promptInstance is the live data about an instance that prompts are rendered with.
+type promptInstance struct {
+	*types.DatabaseInstance
+	Engine      string
+	Tables      []types.TableSchema
+	SchemaError string
+}
+
+// describeInstance returns the live data about an instance, including its tables if the
+// engine supports schema introspection.
+func (h *PromptHandler) describeInstance(ctx context.Context, id string) (*promptInstance, error) {
+	instance, err := h.manager.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	described := &promptInstance{DatabaseInstance: instance, Engine: engineDisplayName(instance.Type)}
+	engine, _ := types.LookupEngine(instance.Type)
+	switch {
+	case engine.ColumnsQuery == "":
+		described.SchemaError = fmt.Sprintf("%s instances do not support schema introspection", described.Engine)
+	case instance.Status != "running":
+		described.SchemaError = fmt.Sprintf("the instance is %s", instance.Status)
+	default:
+		described.Tables, err = h.manager.DescribeSchema(ctx, instance.ID)
+		if err != nil {
+			described.SchemaError = err.Error()
+		}
+	}
+
+	return described, nil
+}
+
+// provisionTemplate renders the provision_test_database prompt.
+var provisionTemplate = template.Must(template.New("provision_test_database").Parse(
+	`I want to test a database migration against a throwaway {{.Engine}} {{.Version}} database before applying it for real.
+
+Migration:
+{{.Migration}}
+
+{{if .Source -}}
+The migration must be tested against a copy of instance {{.Source.ID}} ({{.Source.Engine}} {{.Source.Version}}, database {{.Source.Database}}), which must not be modified.
+{{- if .Source.Tables}} Its tables are: {{range $i, $t := .Source.Tables}}{{if $i}}, {{end}}{{$t.Name}}{{end}}.{{else if .Source.SchemaError}} Its tables could not be listed: {{.Source.SchemaError}}.{{else}} It has no tables yet.{{end}}
+
+1. Copy it with clone_database_instance (instance_id "{{.Source.ID}}", ttl "2h"), and use the clone for all further steps.
+{{- else -}}
+1. Create the database with create_database_instance (type "{{.Type}}", version "{{.Version}}", ttl "2h").
+{{- end}}
+2. Record the schema before the migration{{if .SQL}} by querying the information schema with execute_sql{{end}}.
+3. Apply the migration{{if .SQL}} with execute_sql, one statement at a time{{else}} with exec_in_instance or the engine's client{{end}}, and stop at the first error.
+4. Record the schema after the migration and verify that it changed as intended: new and altered tables, columns, types, constraints and indexes, and that existing data survived.
+5. If the migration has a down migration, apply it and check that the original schema is restored.
+6. Summarize what worked and what failed, with the exact errors, then drop the instance with drop_database_instance.
+`))
+
+// handleProvisionTestDatabase renders the provision_test_database prompt.
+func (h *PromptHandler) handleProvisionTestDatabase(ctx context.Context, arguments map[string]string) (*mcp.GetPromptResult, error) {
+	migration := strings.TrimSpace(arguments["migration"])
+	if migration == "" {
+		return nil, fmt.Errorf("migration argument is required")
+	}
+
+	data := struct {
+		Type      types.DatabaseType
+		Version   string
+		Engine    string
+		SQL       bool
+		Migration string
+		Source    *promptInstance
+	}{
+		Type:      types.DatabaseType(arguments["type"]),
+		Version:   arguments["version"],
+		Migration: migration,
+	}
+
+	if sourceID := arguments["source_instance_id"]; sourceID != "" {
+		source, err := h.describeInstance(ctx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if data.Type != "" && data.Type != source.Type {
+			return nil, fmt.Errorf("instance %s is a %s instance, not %s", source.ID, source.Type, data.Type)
+		}
+		data.Source = source
+		data.Type = source.Type
+		if data.Version == "" {
+			data.Version = source.Version
+		}
+	}
+
+	if data.Type == "" {
+		data.Type = types.DatabaseTypePostgreSQL
+	}
+	engine, ok := types.LookupEngine(data.Type)
+	if !ok {
+		return nil, fmt.Errorf("unsupported database type: %s", data.Type)
+	}
+	if data.Version == "" {
+		data.Version = engine.DefaultVersion
+	}
+	data.Engine = engine.DisplayName
+	data.SQL = engine.Capabilities().SQL
+
+	return renderPrompt(provisionTemplate, fmt.Sprintf("Test a migration on a throwaway %s %s database", data.Engine, data.Version), data)
+}
+
+// queryPlanHelp is the engine-specific advice of the diagnose_slow_query prompt.
+type queryPlanHelp struct {
+	Explain string
+	Indexes string
+}
+
+// queryPlanHelps holds the query plan advice for the engines that support SQL.
+var queryPlanHelps = map[types.DatabaseType]queryPlanHelp{
+	types.DatabaseTypePostgreSQL: {
+		Explain: "EXPLAIN (ANALYZE, BUFFERS) <query>",
+		Indexes: "SELECT tablename, indexname, indexdef FROM pg_indexes WHERE schemaname = current_schema()",
+	},
+	types.DatabaseTypeMySQL: {
+		Explain: "EXPLAIN ANALYZE <query> (or EXPLAIN FORMAT=JSON <query> before MySQL 8.0.18)",
+		Indexes: "SHOW INDEX FROM <table>",
+	},
+	types.DatabaseTypeMariaDB: {
+		Explain: "ANALYZE FORMAT=JSON <query>",
+		Indexes: "SHOW INDEX FROM <table>",
+	},
+	types.DatabaseTypeCockroachDB: {
+		Explain: "EXPLAIN ANALYZE <query>",
+		Indexes: "SHOW INDEXES FROM <table>",
+	},
+}
+
+// diagnoseTemplate renders the diagnose_slow_query prompt.
+var diagnoseTemplate = template.Must(template.New("diagnose_slow_query").Parse(
+	`Diagnose why this query is slow on instance {{.Instance.ID}} ({{.Instance.Engine}} {{.Instance.Version}}, database {{.Instance.Database}}):
+
+{{.Query}}
+
+{{if .Tables -}}
+The tables it uses:{{range .Tables}}
+- {{.Name}}: {{range $i, $c := .Columns}}{{if $i}}, {{end}}{{$c.Name}} {{$c.Type}}{{end}}{{end}}
+{{- else if .Instance.SchemaError -}}
+The instance's tables could not be listed: {{.Instance.SchemaError}}.
+{{- else -}}
+None of the instance's tables{{if .Instance.Tables}} ({{range $i, $t := .Instance.Tables}}{{if $i}}, {{end}}{{$t.Name}}{{end}}){{end}} appear in the query.
+{{- end}}
+
+1. Get the query plan with execute_sql: {{if .Help.Explain}}{{.Help.Explain}}{{else}}use the engine's query plan facility{{end}}. It runs the query, so wrap data-modifying statements in a transaction that is rolled back.
+2. List the existing indexes{{if .Help.Indexes}} with {{.Help.Indexes}}{{end}}.
+3. Identify the expensive steps: sequential or full scans of large tables, poor row estimates, nested loops over many rows, sorts and hashes that spill to disk.
+4. Propose fixes, such as indexes, rewrites of the query or updated statistics, and explain why each helps.
+5. Apply the most promising fix and compare the new plan and timing with the original one. Only change a copy of the instance (clone_database_instance) if it holds data that must not be changed.
+`))
+
+// handleDiagnoseSlowQuery renders the diagnose_slow_query prompt.
+func (h *PromptHandler) handleDiagnoseSlowQuery(ctx context.Context, arguments map[string]string) (*mcp.GetPromptResult, error) {
+	instanceID := arguments["instance_id"]
+	query := strings.TrimSpace(arguments["query"])
+	if instanceID == "" || query == "" {
+		return nil, fmt.Errorf("instance_id and query arguments are required")
+	}
+
+	instance, err := h.describeInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Instance *promptInstance
+		Query    string
+		Tables   []types.TableSchema
+		Help     queryPlanHelp
+	}{
+		Instance: instance,
+		Query:    query,
+		Tables:   tablesInQuery(instance.Tables, query),
+		Help:     queryPlanHelps[instance.Type],
+	}
+
+	return renderPrompt(diagnoseTemplate, fmt.Sprintf("Diagnose a slow query on %s instance %s", instance.Engine, instance.ID), data)
+}
+
+// tablesInQuery returns the tables whose names appear as words in a query.
+func tablesInQuery(tables []types.TableSchema, query string) []types.TableSchema {
+	words := regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_$]*`).FindAllString(strings.ToLower(query), -1)
+	return slices.DeleteFunc(slices.Clone(tables), func(table types.TableSchema) bool {
+		return !slices.Contains(words, strings.ToLower(table.Name))
+	})
+}
+
+// compareTemplate renders the compare_schemas prompt.
+var compareTemplate = template.Must(template.New("compare_schemas").Parse(
+	`Compare the schema of instance {{.Source.ID}} (the current schema) with the schema of instance {{.Target.ID}} (the desired schema).
+{{range .Instances}}
+{{.ID}} ({{.Engine}} {{.Version}}, database {{.Database}}):
+{{if .SchemaError}}The tables could not be listed: {{.SchemaError}}. Inspect the schema with execute_sql or exec_in_instance instead.
+{{else if .Tables}}{{range .Tables}}- {{.Name}}: {{range $i, $c := .Columns}}{{if $i}}, {{end}}{{$c.Name}} {{$c.Type}}{{if not $c.Nullable}} NOT NULL{{end}}{{end}}
+{{end}}{{else}}No tables.
+{{end}}{{end}}
+{{- if ne .Source.Type .Target.Type}}
+The instances run different engines, so compare the logical schema and ignore differences that only come from the engines' type names.
+{{end}}
+1. List the tables that only exist in one of the instances, and the columns that were added, removed or changed type or nullability.
+2. Check the differences that the column lists do not show with execute_sql: primary keys, foreign keys, unique constraints, indexes, defaults and views.
+3. Write a migration that turns the current schema into the desired one, in a safe order, and point out steps that can lose data.
+4. Offer to verify the migration by cloning {{.Source.ID}} with clone_database_instance, applying it to the clone and comparing the result with {{.Target.ID}}.
+`))
+
+// handleCompareSchemas renders the compare_schemas prompt.
+func (h *PromptHandler) handleCompareSchemas(ctx context.Context, arguments map[string]string) (*mcp.GetPromptResult, error) {
+	sourceID := arguments["source_instance_id"]
+	targetID := arguments["target_instance_id"]
+	if sourceID == "" || targetID == "" {
+		return nil, fmt.Errorf("source_instance_id and target_instance_id arguments are required")
+	}
+
+	source, err := h.describeInstance(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := h.describeInstance(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Source    *promptInstance
+		Target    *promptInstance
+		Instances []*promptInstance
+	}{
+		Source:    source,
+		Target:    target,
+		Instances: []*promptInstance{source, target},
+	}
+
+	return renderPrompt(compareTemplate, fmt.Sprintf("Compare the schemas of instances %s and %s", source.ID, target.ID), data)
+}
+
+// renderPrompt renders a prompt template into a single user message.
+func renderPrompt(tmpl *template.Template, description string, data any) (*mcp.GetPromptResult, error) {
+	var text strings.Builder
+	if err := tmpl.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render prompt %s: %w", tmpl.Name(), err)
+	}
+
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text.String())),
+	}), nil
+}
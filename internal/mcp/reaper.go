@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// DefaultReapInterval is how often the reaper checks for expired and idle instances.
+const DefaultReapInterval = time.Minute
+
+// ReaperConfig holds configuration for the instance reaper.
+type ReaperConfig struct {
+	// Interval is how often instances are checked (defaults to DefaultReapInterval).
+	Interval time.Duration
+
+	// IdleTimeout drops instances owned by OwnerID that have had no client connections
+	// for this long. Zero disables idle reaping.
+	IdleTimeout time.Duration
+
+	// OwnerID limits idle reaping to the instances created by this server.
+	OwnerID string
+}
+
+// Reaper periodically drops instances whose TTL has expired and, optionally, owned
+// instances that have been idle for longer than the configured timeout.
+type Reaper struct {
+	manager *database.UnifiedManager
+	config  ReaperConfig
+
+	mu         sync.Mutex
+	lastActive map[string]time.Time
+}
+
+// NewReaper creates a new instance reaper.
+func NewReaper(manager *database.UnifiedManager, config ReaperConfig) *Reaper {
+	if config.Interval <= 0 {
+		config.Interval = DefaultReapInterval
+	}
+
+	return &Reaper{
+		manager:    manager,
+		config:     config,
+		lastActive: make(map[string]time.Time),
+	}
+}
+
+// Run checks instances every interval until the context is cancelled.
+func (r *Reaper) Run(ctx context.Context) {
+	slog.Info("Starting instance reaper", "interval", r.config.Interval, "idle_timeout", r.config.IdleTimeout)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Instance reaper stopped")
+			return
+		case <-ticker.C:
+			r.Reap(ctx)
+		}
+	}
+}
+
+// Reap performs a single pass over all instances and drops the expired and idle ones.
+func (r *Reaper) Reap(ctx context.Context) {
+	instances, err := r.manager.ListInstances(ctx)
+	if err != nil {
+		slog.Warn("Reaper failed to list instances", "error", err)
+		return
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool, len(instances))
+
+	for _, instance := range instances {
+		seen[instance.ID] = true
+
+		if instance.IsExpired(now) {
+			r.drop(ctx, instance, "ttl expired")
+			continue
+		}
+
+		if r.isIdle(ctx, instance, now) {
+			r.drop(ctx, instance, "idle timeout")
+		}
+	}
+
+	// Forget instances that no longer exist
+	r.mu.Lock()
+	for id := range r.lastActive {
+		if !seen[id] {
+			delete(r.lastActive, id)
+		}
+	}
+	r.mu.Unlock()
+}
+
+// isIdle reports whether an instance has had no client connections for at least the idle timeout.
+func (r *Reaper) isIdle(ctx context.Context, instance *types.DatabaseInstance, now time.Time) bool {
+	if r.config.IdleTimeout <= 0 || r.config.OwnerID == "" || instance.Owner != r.config.OwnerID {
+		return false
+	}
+
+	if instance.Status != "running" {
+		return false
+	}
+
+	r.mu.Lock()
+	lastActive, tracked := r.lastActive[instance.ID]
+	r.mu.Unlock()
+
+	if !tracked {
+		// Start the idle clock at creation time, or now if the creation time is unknown
+		lastActive = instance.CreatedAt
+		if lastActive.IsZero() {
+			lastActive = now
+		}
+	}
+
+	connections, err := r.manager.ActiveConnections(ctx, instance.ID)
+	if err != nil {
+		// Treat errors as activity: an instance we cannot inspect should not be dropped
+		slog.Debug("Reaper failed to count connections", "instance_id", instance.ID, "error", err)
+		lastActive = now
+	} else if connections > 0 {
+		lastActive = now
+	}
+
+	r.mu.Lock()
+	r.lastActive[instance.ID] = lastActive
+	r.mu.Unlock()
+
+	return now.Sub(lastActive) >= r.config.IdleTimeout
+}
+
+// drop removes an instance and logs the reason.
+func (r *Reaper) drop(ctx context.Context, instance *types.DatabaseInstance, reason string) {
+	slog.Info("Reaping database instance", "instance_id", instance.ID, "type", instance.Type, "reason", reason)
+
+	if err := r.manager.DropInstance(ctx, instance.ID); err != nil {
+		slog.Error("Failed to reap database instance", "instance_id", instance.ID, "error", err)
+		return
+	}
+
+	r.mu.Lock()
+	delete(r.lastActive, instance.ID)
+	r.mu.Unlock()
+}
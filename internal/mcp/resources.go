@@ -0,0 +1,301 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// InstanceResourceScheme is the URI scheme of instance resources: dbinstance://<id> for an
+// instance and dbinstance://<id>/tables/<name> for a table in its database.
+const InstanceResourceScheme = "dbinstance"
+
+// TableResourceTemplate is the URI template of table resources.
+const TableResourceTemplate = InstanceResourceScheme + "://{instance_id}/tables/{table}"
+
+// DefaultResourceRefreshInterval is how often instances are checked for changes that were not
+// made through the server, such as health changes.
+const DefaultResourceRefreshInterval = 15 * time.Second
+
+// ResourceHandler publishes the managed instances as MCP resources and keeps them up to date.
+// Changes to the resource list are announced with notifications/resources/list_changed.
+type ResourceHandler struct {
+	manager   *database.UnifiedManager
+	mcpServer *server.MCPServer
+	interval  time.Duration
+	changed   chan struct{}
+
+	mu         sync.Mutex
+	principals map[string]string // principal of each published instance resource, by URI
+}
+
+// NewResourceHandler creates a resource handler publishing the manager's instances on the
+// MCP server. Instances are checked for changes every interval (default:
+// DefaultResourceRefreshInterval).
+func NewResourceHandler(manager *database.UnifiedManager, mcpServer *server.MCPServer, interval time.Duration) *ResourceHandler {
+	if interval <= 0 {
+		interval = DefaultResourceRefreshInterval
+	}
+
+	h := &ResourceHandler{
+		manager:    manager,
+		mcpServer:  mcpServer,
+		interval:   interval,
+		changed:    make(chan struct{}, 1),
+		principals: make(map[string]string),
+	}
+
+	mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(TableResourceTemplate, "Database table",
+			mcp.WithTemplateDescription("Columns of a table in a database instance"),
+			mcp.WithTemplateMIMEType("application/json")),
+		h.handleTableResource)
+	manager.Subscribe(h.handleInstanceEvent)
+
+	return h
+}
+
+// Run publishes the instances and republishes them whenever they change, until the context is
+// cancelled.
+func (h *ResourceHandler) Run(ctx context.Context) {
+	h.refresh(ctx)
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.changed:
+			h.refresh(ctx)
+		case <-ticker.C:
+			// Checking the containers publishes the changes found, which trigger a refresh
+			if err := h.manager.CheckForChanges(ctx); err != nil {
+				slog.Warn("Failed to check instances for changes", "error", err)
+			}
+		}
+	}
+}
+
+// handleInstanceEvent schedules a refresh of the published resources.
+func (h *ResourceHandler) handleInstanceEvent(event types.InstanceEvent) {
+	slog.Debug("Instance changed", "instance_id", event.InstanceID, "change", event.Type, "status", event.Status)
+
+	select {
+	case h.changed <- struct{}{}:
+	default:
+		// A refresh is already pending
+	}
+}
+
+// refresh replaces the published instance resources with the current instances. Publishing
+// notifies the clients that the resource list changed.
+func (h *ResourceHandler) refresh(ctx context.Context) {
+	// The server's context has no principal, so the instances of all principals are listed and
+	// FilterResources hides them from the clients that may not access them
+	instances, err := h.manager.ListInstances(ctx)
+	if err != nil {
+		slog.Warn("Failed to list instances for resources", "error", err)
+		return
+	}
+
+	resources := make([]server.ServerResource, 0, len(instances))
+	principals := make(map[string]string, len(instances))
+	for _, instance := range instances {
+		uri := instanceResourceURI(instance.ID)
+		principals[uri] = instance.Principal
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(uri, fmt.Sprintf("%s instance %s", engineDisplayName(instance.Type), instance.ID),
+				mcp.WithResourceDescription(fmt.Sprintf("%s %s database %s on port %d (%s)",
+					engineDisplayName(instance.Type), instance.Version, instance.Database, instance.Port, instance.Status)),
+				mcp.WithMIMEType("application/json")),
+			Handler: h.handleInstanceResource,
+		})
+	}
+
+	h.mu.Lock()
+	var removed []string
+	for uri := range h.principals {
+		if _, exists := principals[uri]; !exists {
+			removed = append(removed, uri)
+		}
+	}
+	h.principals = principals
+	h.mu.Unlock()
+
+	h.mcpServer.AddResources(resources...)
+	if len(removed) > 0 {
+		h.mcpServer.DeleteResources(removed...)
+	}
+}
+
+// FilterResources removes the instances that the requesting principal may not access from a
+// resources/list result.
+func (h *ResourceHandler) FilterResources(ctx context.Context, _ any, _ *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+	principal := types.PrincipalFromContext(ctx)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result.Resources = slices.DeleteFunc(result.Resources, func(resource mcp.Resource) bool {
+		owner, isInstance := h.principals[resource.URI]
+		return isInstance && !principal.CanAccess(owner)
+	})
+}
+
+// instanceResource is the content of an instance resource.
+type instanceResource struct {
+	Instance    *types.DatabaseInstance `json:"instance"`
+	Connection  instanceConnection      `json:"connection"`
+	Schema      *schemaSummary          `json:"schema,omitempty"`
+	SchemaError string                  `json:"schema_error,omitempty"`
+}
+
+// instanceConnection is the connection information of an instance resource.
+type instanceConnection struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	DSN      string `json:"dsn"`
+}
+
+// schemaSummary lists the tables of an instance resource.
+type schemaSummary struct {
+	Tables []tableSummary `json:"tables"`
+}
+
+// tableSummary describes a table in a schema summary.
+type tableSummary struct {
+	Name    string `json:"name"`
+	Columns int    `json:"columns"`
+	URI     string `json:"uri"`
+}
+
+// handleInstanceResource returns an instance's details, connection information and a summary
+// of its database schema.
+func (h *ResourceHandler) handleInstanceResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	id := strings.TrimPrefix(request.Params.URI, InstanceResourceScheme+"://")
+
+	instance, err := h.manager.GetInstance(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	content := instanceResource{
+		Instance: instance,
+		Connection: instanceConnection{
+			Host:     "localhost",
+			Port:     instance.Port,
+			Database: instance.Database,
+			Username: instance.Username,
+			Password: instance.Password,
+			DSN:      instance.DSN,
+		},
+	}
+
+	// Only running instances can be introspected, and not every engine supports it
+	engine, _ := types.LookupEngine(instance.Type)
+	if engine.ColumnsQuery != "" && instance.Status == "running" {
+		tables, err := h.manager.DescribeSchema(ctx, instance.ID)
+		if err != nil {
+			content.SchemaError = err.Error()
+		} else {
+			content.Schema = &schemaSummary{Tables: make([]tableSummary, 0, len(tables))}
+			for _, table := range tables {
+				content.Schema.Tables = append(content.Schema.Tables, tableSummary{
+					Name:    table.Name,
+					Columns: len(table.Columns),
+					URI:     tableResourceURI(instance.ID, table.Name),
+				})
+			}
+		}
+	}
+
+	return jsonResourceContents(request.Params.URI, content)
+}
+
+// handleTableResource returns the columns of a table in an instance's database.
+func (h *ResourceHandler) handleTableResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	id := templateArgument(request.Params.Arguments, "instance_id")
+	name := templateArgument(request.Params.Arguments, "table")
+	if id == "" || name == "" {
+		return nil, fmt.Errorf("invalid table resource URI: %s", request.Params.URI)
+	}
+
+	tables, err := h.manager.DescribeSchema(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		if table.Name == name {
+			return jsonResourceContents(request.Params.URI, table)
+		}
+	}
+
+	return nil, fmt.Errorf("table %s not found in instance %s", name, id)
+}
+
+// jsonResourceContents returns a value as the JSON content of a resource.
+func jsonResourceContents(uri string, value any) ([]mcp.ResourceContents, error) {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format resource: %w", err)
+	}
+
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      uri,
+		MIMEType: "application/json",
+		Text:     string(content),
+	}}, nil
+}
+
+// templateArgument returns the unescaped value of a URI template variable.
+func templateArgument(arguments map[string]any, name string) string {
+	var value string
+	switch v := arguments[name].(type) {
+	case string:
+		value = v
+	case []string:
+		if len(v) > 0 {
+			value = v[0]
+		}
+	}
+
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// instanceResourceURI returns the URI of an instance resource.
+func instanceResourceURI(id string) string {
+	return InstanceResourceScheme + "://" + id
+}
+
+// tableResourceURI returns the URI of a table resource.
+func tableResourceURI(id, table string) string {
+	return instanceResourceURI(id) + "/tables/" + url.PathEscape(table)
+}
+
+// engineDisplayName returns the display name of a database type.
+func engineDisplayName(dbType types.DatabaseType) string {
+	if engine, ok := types.LookupEngine(dbType); ok && engine.DisplayName != "" {
+		return engine.DisplayName
+	}
+	return string(dbType)
+}
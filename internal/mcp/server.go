@@ -2,23 +2,58 @@ package mcp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/stokaro/dev-postgres-mcp/internal/database"
 	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+// Transports the MCP server can serve clients over.
+const (
+	// TransportStdio serves a single client over standard input and output.
+	TransportStdio = "stdio"
+	// TransportSSE serves clients over HTTP with server-sent events (the 2024-11-05 protocol).
+	TransportSSE = "sse"
+	// TransportHTTP serves clients over streamable HTTP.
+	TransportHTTP = "http"
+)
+
+// DefaultListenAddr is the address the network transports listen on by default.
+const DefaultListenAddr = "127.0.0.1:8080"
+
+// ShutdownTimeout is how long the network transports wait for open requests on shutdown.
+const ShutdownTimeout = 10 * time.Second
+
+// Endpoint paths of the network transports.
+const (
+	SSEEndpoint     = "/sse"
+	MessageEndpoint = "/message"
+	HTTPEndpoint    = "/mcp"
 )
 
 // Server represents the MCP server for database instance management.
 type Server struct {
 	mcpServer      *server.MCPServer
+	transport      string
+	listenAddr     string
 	stdioServer    *server.StdioServer
+	httpServer     *http.Server
+	shutdown       func(context.Context) error
+	authTokens     []APIToken
 	toolHandler    *ToolHandler
+	resources      *ResourceHandler
 	unifiedManager *database.UnifiedManager
 	dockerMgr      *docker.Manager
+	reaper         *Reaper
 }
 
 // ServerConfig holds configuration for the MCP server.
@@ -28,10 +63,88 @@ type ServerConfig struct {
 	StartPort int
 	EndPort   int
 	LogLevel  string
+
+	// Transport is the transport clients connect over: stdio (default), sse or http.
+	Transport string
+
+	// ListenAddr is the address the sse and http transports listen on (default: DefaultListenAddr).
+	ListenAddr string
+
+	// AuthTokens are the API tokens clients of the sse and http transports authenticate with.
+	// Each client only sees the instances of its token's principal, unless the token is an
+	// admin token. Without tokens, the network transports do not authenticate clients.
+	AuthTokens []APIToken
+
+	// AllowUnauthenticated permits serving the network transports without AuthTokens on an
+	// address that is not a loopback address.
+	AllowUnauthenticated bool
+
+	// OwnerID labels the instances created by this server. A random ID is generated if empty;
+	// reusing an ID lets a restarted server treat its predecessor's instances as its own.
+	OwnerID string
+
+	// CleanupPolicy determines which instances are removed on shutdown (default: owned).
+	CleanupPolicy types.CleanupPolicy
+
+	// ReapInterval is how often expired and idle instances are reaped (default: DefaultReapInterval).
+	ReapInterval time.Duration
+
+	// IdleTimeout drops owned instances without client connections for this long (zero disables).
+	IdleTimeout time.Duration
+
+	// Resources holds the resource defaults and maximums applied to new instances.
+	Resources types.ResourcePolicy
+
+	// Quotas limits the number and total memory of the instances the server creates.
+	Quotas types.QuotaPolicy
+
+	// InitScripts lists the host directories and URL hosts that clients of the sse and http
+	// transports may load init scripts from. Without it they may only pass inline SQL.
+	InitScripts types.InitScriptPolicy
 }
 
 // NewServer creates a new MCP server.
 func NewServer(config ServerConfig) (*Server, error) {
+	// Validate the configuration before connecting to Docker
+	if config.Transport == "" {
+		config.Transport = TransportStdio
+	}
+	if config.ListenAddr == "" {
+		config.ListenAddr = DefaultListenAddr
+	}
+	switch config.Transport {
+	case TransportStdio, TransportSSE, TransportHTTP:
+	default:
+		return nil, fmt.Errorf("invalid transport: %s (expected stdio, sse or http)", config.Transport)
+	}
+	if err := ValidateAuthTokens(config.AuthTokens); err != nil {
+		return nil, fmt.Errorf("invalid auth tokens: %w", err)
+	}
+	if config.Transport != TransportStdio && len(config.AuthTokens) == 0 && !config.AllowUnauthenticated && !isLoopbackAddr(config.ListenAddr) {
+		return nil, fmt.Errorf("refusing to serve unauthenticated clients on %s: configure auth tokens or allow unauthenticated access", config.ListenAddr)
+	}
+	if config.Transport == TransportStdio && len(config.AuthTokens) > 0 {
+		slog.Warn("Auth tokens are ignored by the stdio transport")
+	}
+
+	// Resolve instance ownership and cleanup policy
+	if config.OwnerID == "" {
+		config.OwnerID = types.GenerateInstanceID()
+	}
+	if config.CleanupPolicy == "" {
+		config.CleanupPolicy = types.CleanupPolicyOwned
+	}
+	if !config.CleanupPolicy.IsValid() {
+		return nil, fmt.Errorf("invalid cleanup policy: %s (expected all, owned or none)", config.CleanupPolicy)
+	}
+
+	if err := config.Resources.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resource limits: %w", err)
+	}
+	if err := config.Quotas.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quotas: %w", err)
+	}
+
 	// Create Docker manager
 	dockerMgr, err := docker.NewManager(config.StartPort, config.EndPort)
 	if err != nil {
@@ -45,14 +158,29 @@ func NewServer(config ServerConfig) (*Server, error) {
 		return nil, fmt.Errorf("Docker daemon is not accessible: %w", err)
 	}
 
+	slog.Info("Configured instance ownership", "owner_id", config.OwnerID, "cleanup_policy", config.CleanupPolicy)
+
 	// Create unified database manager
-	unifiedManager := database.NewUnifiedManager(dockerMgr)
+	managerConfig := database.DefaultManagerConfig()
+	managerConfig.OwnerID = config.OwnerID
+	managerConfig.CleanupPolicy = config.CleanupPolicy
+	managerConfig.Resources = config.Resources
+	managerConfig.Quotas = config.Quotas
+	managerConfig.InitScripts = config.InitScripts
+	managerConfig.InitScripts.Restricted = config.Transport != TransportStdio
+	unifiedManager := database.NewUnifiedManagerWithConfig(dockerMgr, managerConfig)
 
 	// Create tool handler
 	toolHandler := NewToolHandler(unifiedManager)
 
-	// Create MCP server
-	mcpServer := server.NewMCPServer(config.Name, config.Version)
+	// Create MCP server. Instances are published as resources, and the hooks hide the instances
+	// of other principals from resources/list.
+	hooks := &server.Hooks{}
+	mcpServer := server.NewMCPServer(config.Name, config.Version,
+		server.WithResourceCapabilities(false, true),
+		server.WithHooks(hooks))
+	resourceHandler := NewResourceHandler(unifiedManager, mcpServer, 0)
+	hooks.AddAfterListResources(resourceHandler.FilterResources)
 
 	// Add tools to the server
 	tools := toolHandler.GetTools()
@@ -60,32 +188,140 @@ func NewServer(config ServerConfig) (*Server, error) {
 		mcpServer.AddTool(tool, toolHandler.HandleTool)
 	}
 
-	// Create stdio server wrapper
-	stdioServer := server.NewStdioServer(mcpServer)
+	// Add prompts for common database workflows
+	promptHandler := NewPromptHandler(unifiedManager)
+	for _, prompt := range promptHandler.GetPrompts() {
+		mcpServer.AddPrompt(prompt, promptHandler.HandlePrompt)
+	}
+
+	// Create reaper for expired and idle instances
+	reaper := NewReaper(unifiedManager, ReaperConfig{
+		Interval:    config.ReapInterval,
+		IdleTimeout: config.IdleTimeout,
+		OwnerID:     config.OwnerID,
+	})
 
-	return &Server{
+	s := &Server{
 		mcpServer:      mcpServer,
-		stdioServer:    stdioServer,
+		transport:      config.Transport,
+		listenAddr:     config.ListenAddr,
+		authTokens:     config.AuthTokens,
 		toolHandler:    toolHandler,
+		resources:      resourceHandler,
 		unifiedManager: unifiedManager,
 		dockerMgr:      dockerMgr,
-	}, nil
+		reaper:         reaper,
+	}
+	s.setupTransport()
+
+	return s, nil
+}
+
+// setupTransport creates the server for the configured transport. The network transports
+// share an HTTP server whose shutdown also closes their open sessions.
+func (s *Server) setupTransport() {
+	switch s.transport {
+	case TransportSSE:
+		s.httpServer = &http.Server{ReadHeaderTimeout: 10 * time.Second}
+		sseServer := server.NewSSEServer(s.mcpServer,
+			server.WithHTTPServer(s.httpServer),
+			server.WithSSEEndpoint(SSEEndpoint),
+			server.WithMessageEndpoint(MessageEndpoint),
+			server.WithKeepAlive(true))
+		s.httpServer.Handler = sseServer
+		s.shutdown = sseServer.Shutdown
+	case TransportHTTP:
+		s.httpServer = &http.Server{ReadHeaderTimeout: 10 * time.Second}
+		httpServer := server.NewStreamableHTTPServer(s.mcpServer,
+			server.WithStreamableHTTPServer(s.httpServer),
+			server.WithEndpointPath(HTTPEndpoint))
+		mux := http.NewServeMux()
+		mux.Handle(HTTPEndpoint, httpServer)
+		s.httpServer.Handler = mux
+		s.shutdown = httpServer.Shutdown
+	default:
+		s.stdioServer = server.NewStdioServer(s.mcpServer)
+		return
+	}
+
+	if len(s.authTokens) > 0 {
+		s.httpServer.Handler = NewAuthHandler(s.httpServer.Handler, s.authTokens)
+	}
+	s.httpServer.Handler = NewOriginHandler(s.httpServer.Handler, s.listenAddr)
+}
+
+// isLoopbackAddr reports whether a listen address only accepts connections from this host.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	return err == nil && isLoopbackHost(host)
 }
 
 // Start starts the MCP server.
 func (s *Server) Start(ctx context.Context) error {
 	slog.Info("Starting MCP server for database instance management")
 
-	// Start the stdio server
-	slog.Info("MCP server started, waiting for requests...")
-	return s.stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+	// Start reaping expired and idle instances in the background
+	go s.reaper.Run(ctx)
+
+	// Publish the instances as resources and keep them up to date
+	go s.resources.Run(ctx)
+
+	if s.stdioServer != nil {
+		// Start the stdio server
+		slog.Info("MCP server started, waiting for requests...")
+		return s.stdioServer.Listen(ctx, os.Stdin, os.Stdout)
+	}
+
+	return s.serveHTTP(ctx)
+}
+
+// serveHTTP serves the network transport until the context is cancelled, then shuts it down
+// gracefully, giving open requests up to ShutdownTimeout to finish.
+func (s *Server) serveHTTP(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.listenAddr, err)
+	}
+
+	endpoints := []string{HTTPEndpoint}
+	if s.transport == TransportSSE {
+		endpoints = []string{SSEEndpoint, MessageEndpoint}
+	}
+	slog.Info("MCP server started, waiting for connections...",
+		"transport", s.transport,
+		"address", listener.Addr().String(),
+		"endpoints", endpoints,
+		"authentication", len(s.authTokens) > 0)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("MCP %s server failed: %w", s.transport, err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down MCP server transport", "transport", s.transport)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	if err := s.shutdown(shutdownCtx); err != nil {
+		slog.Warn("MCP server transport did not shut down cleanly", "transport", s.transport, "error", err)
+	}
+
+	return ctx.Err()
 }
 
 // Stop stops the MCP server and cleans up resources.
 func (s *Server) Stop(ctx context.Context) error {
 	slog.Info("Stopping MCP server")
 
-	// Cleanup all database instances
+	// Cleanup database instances according to the cleanup policy
 	if err := s.unifiedManager.Cleanup(ctx); err != nil {
 		slog.Error("Failed to cleanup database instances", "error", err)
 	}
@@ -103,7 +339,7 @@ func (s *Server) Stop(ctx context.Context) error {
 func (s *Server) Close() error {
 	ctx := context.Background()
 
-	// Cleanup all database instances
+	// Cleanup database instances according to the cleanup policy
 	if err := s.unifiedManager.Cleanup(ctx); err != nil {
 		slog.Error("Failed to cleanup database instances", "error", err)
 	}
@@ -136,5 +372,12 @@ func (s *Server) GetServerCapabilities() map[string]any {
 		"tools": map[string]any{
 			"listChanged": false,
 		},
+		"resources": map[string]any{
+			"subscribe":   false,
+			"listChanged": true,
+		},
+		"prompts": map[string]any{
+			"listChanged": false,
+		},
 	}
 }
@@ -4,10 +4,15 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/stokaro/dev-postgres-mcp/internal/database"
 	"github.com/stokaro/dev-postgres-mcp/pkg/types"
@@ -27,18 +32,31 @@ func NewToolHandler(manager *database.UnifiedManager) *ToolHandler {
 
 // GetTools returns the list of available MCP tools.
 func (h *ToolHandler) GetTools() []mcp.Tool {
+	engineTypes := types.EngineTypeNames()
+
 	return []mcp.Tool{
 		mcp.NewTool("create_database_instance",
 			mcp.WithDescription("Create a new ephemeral database instance in a Docker container"),
-			mcp.WithString("type", mcp.Description("Database type: postgresql, mysql, or mariadb (default: postgresql)")),
+			mcp.WithString("type", mcp.Description(fmt.Sprintf("Database type: %s (default: %s)", strings.Join(engineTypes, ", "), types.DatabaseTypePostgreSQL)),
+				mcp.Enum(engineTypes...)),
 			mcp.WithString("version", mcp.Description("Database version to use (defaults vary by type)")),
 			mcp.WithString("database", mcp.Description("Database name to create (defaults vary by type)")),
 			mcp.WithString("username", mcp.Description("Database username (defaults vary by type)")),
 			mcp.WithString("password", mcp.Description("Database password (auto-generated if not provided)")),
+			mcp.WithString("ttl", mcp.Description("Time-to-live after which the instance is dropped automatically, e.g. \"30m\" or \"2h\" (default: no limit)")),
+			mcp.WithBoolean("persistent", mcp.Description("Store data in a managed volume that can be detached and reattached (default: false)")),
+			mcp.WithString("memory", mcp.Description("Container memory limit, e.g. \"256m\" or \"2g\" (default: server default, at least what the engine needs)")),
+			mcp.WithNumber("cpus", mcp.Description("Number of CPUs the container may use, e.g. 0.5 or 2 (default: server default)")),
+			mcp.WithString("shm_size", mcp.Description("Size of /dev/shm, e.g. \"256m\" (default: 64m)")),
+			mcp.WithBoolean("tmpfs", mcp.Description("Keep the data directory in memory on a tmpfs mount; faster, but counts towards the memory limit and cannot be combined with persistent (default: false)")),
+			mcp.WithArray("init_scripts",
+				mcp.Description("SQL scripts applied in order once the instance is healthy. Each entry is inline SQL, a host file path, a host directory of *.sql files (applied in name order), or an http(s) URL. Over an authenticated transport, paths and URLs must be allowed by the server"),
+				mcp.WithStringItems()),
 		),
 		mcp.NewTool("list_database_instances",
 			mcp.WithDescription("List all running database instances"),
-			mcp.WithString("type", mcp.Description("Filter by database type: postgresql, mysql, mariadb (optional)")),
+			mcp.WithString("type", mcp.Description(fmt.Sprintf("Filter by database type: %s (optional)", strings.Join(engineTypes, ", "))),
+				mcp.Enum(engineTypes...)),
 		),
 		mcp.NewTool("get_database_instance",
 			mcp.WithDescription("Get details of a specific database instance"),
@@ -48,10 +66,91 @@ func (h *ToolHandler) GetTools() []mcp.Tool {
 			mcp.WithDescription("Remove a database instance and all its data"),
 			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to remove"), mcp.Required()),
 		),
+		mcp.NewTool("stop_database_instance",
+			mcp.WithDescription("Stop a database instance to free CPU and memory while keeping its container, data and port"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to stop"), mcp.Required()),
+		),
+		mcp.NewTool("start_database_instance",
+			mcp.WithDescription("Start a stopped database instance and wait for it to become healthy"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to start"), mcp.Required()),
+		),
+		mcp.NewTool("restart_database_instance",
+			mcp.WithDescription("Restart a database instance and wait for it to become healthy"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to restart"), mcp.Required()),
+		),
 		mcp.NewTool("health_check_database",
-			mcp.WithDescription("Check the health status of a database instance"),
+			mcp.WithDescription("Check the health of a database instance by connecting with its credentials; reports container status, latency, server version, uptime and active connections"),
 			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to check"), mcp.Required()),
 		),
+		mcp.NewTool("list_database_volumes",
+			mcp.WithDescription("List the data volumes of persistent database instances, including detached ones"),
+		),
+		mcp.NewTool("detach_database_instance",
+			mcp.WithDescription("Remove a persistent database instance's container but keep its data volume for reattaching later"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the persistent database instance to detach"), mcp.Required()),
+		),
+		mcp.NewTool("attach_database_volume",
+			mcp.WithDescription("Recreate a database instance from a detached data volume"),
+			mcp.WithString("volume", mcp.Description("The name of the data volume to attach"), mcp.Required()),
+		),
+		mcp.NewTool("delete_database_volume",
+			mcp.WithDescription("Delete a detached data volume and all its data"),
+			mcp.WithString("volume", mcp.Description("The name of the data volume to delete"), mcp.Required()),
+		),
+		mcp.NewTool("clone_database_instance",
+			mcp.WithDescription("Create a new, independent database instance with a copy of an existing instance's data"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to clone"), mcp.Required()),
+			mcp.WithString("ttl", mcp.Description("Time-to-live of the clone, e.g. \"30m\" or \"2h\" (default: no limit)")),
+			mcp.WithBoolean("persistent", mcp.Description("Store the clone's data in a managed volume (default: false)")),
+		),
+		mcp.NewTool("snapshot_database_instance",
+			mcp.WithDescription("Take a logical snapshot (SQL dump) of a database instance and keep it in the local snapshot store as an undo point"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance to snapshot"), mcp.Required()),
+			mcp.WithString("name", mcp.Description("Optional label for the snapshot")),
+		),
+		mcp.NewTool("restore_database_instance",
+			mcp.WithDescription("Restore a snapshot into an existing database instance, or into a new instance if no target is given"),
+			mcp.WithString("snapshot_id", mcp.Description("The identifier of the snapshot to restore"), mcp.Required()),
+			mcp.WithString("instance_id", mcp.Description("The instance to restore into; its objects contained in the snapshot are replaced (default: create a new instance)")),
+		),
+		mcp.NewTool("list_database_snapshots",
+			mcp.WithDescription("List the snapshots in the local snapshot store"),
+			mcp.WithString("instance_id", mcp.Description("Only list snapshots taken from this instance (optional)")),
+		),
+		mcp.NewTool("delete_database_snapshot",
+			mcp.WithDescription("Delete a snapshot from the local snapshot store"),
+			mcp.WithString("snapshot_id", mcp.Description("The identifier of the snapshot to delete"), mcp.Required()),
+		),
+		mcp.NewTool("exec_in_instance",
+			mcp.WithDescription("Run a command (e.g. psql, pg_dump, mysql, mysqldump) inside a database instance's container. The engine's client password is provided through the environment"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance"), mcp.Required()),
+			mcp.WithArray("command", mcp.Description("The command and its arguments, e.g. [\"psql\", \"-U\", \"postgres\", \"-c\", \"SELECT version()\"]"), mcp.WithStringItems(), mcp.Required()),
+			mcp.WithString("stdin", mcp.Description("Text passed to the command's standard input (optional)")),
+			mcp.WithNumber("timeout_seconds", mcp.Description(fmt.Sprintf("Command timeout in seconds (default: %d, max: %d)",
+				int(types.DefaultExecTimeout.Seconds()), int(types.MaxExecTimeout.Seconds())))),
+			mcp.WithNumber("max_output_bytes", mcp.Description(fmt.Sprintf("Maximum bytes of stdout and of stderr to return (default: %d, max: %d)",
+				types.DefaultExecMaxOutputBytes, types.MaxExecMaxOutputBytes))),
+		),
+		mcp.NewTool("get_instance_logs",
+			mcp.WithDescription("Return the most recent log lines of a database instance's server, optionally filtered by severity or regular expression"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance"), mcp.Required()),
+			mcp.WithNumber("tail", mcp.Description(fmt.Sprintf("Maximum number of lines to return, counted from the end (default: %d, max: %d)", types.DefaultLogTail, types.MaxLogTail))),
+			mcp.WithString("since", mcp.Description("Only consider logs since a timestamp (RFC 3339) or relative duration such as 10m (optional)")),
+			mcp.WithString("level", mcp.Description(fmt.Sprintf("Only return lines of this severity or higher; filters search the last %d lines (default: all)", types.MaxLogScanLines)),
+				mcp.Enum(types.LogLevelError, types.LogLevelWarning, types.LogLevelInfo)),
+			mcp.WithString("pattern", mcp.Description("Only return lines matching this regular expression (optional)")),
+			mcp.WithBoolean("timestamps", mcp.Description("Prefix each line with its timestamp (default: false)")),
+		),
+		mcp.NewTool("execute_sql",
+			mcp.WithDescription("Execute a SQL statement against a database instance and return the resulting rows"),
+			mcp.WithString("instance_id", mcp.Description("The unique identifier of the database instance"), mcp.Required()),
+			mcp.WithString("sql", mcp.Description("The SQL statement to execute"), mcp.Required()),
+			mcp.WithArray("params", mcp.Description("Positional parameters bound to the statement placeholders ($1 for PostgreSQL, ? for MySQL/MariaDB)")),
+			mcp.WithNumber("max_rows", mcp.Description(fmt.Sprintf("Maximum number of rows to return (default: %d, max: %d)", types.DefaultQueryMaxRows, types.MaxQueryRows))),
+			mcp.WithNumber("max_bytes", mcp.Description(fmt.Sprintf("Maximum size of the returned rows in bytes (default: %d, max: %d)", types.DefaultQueryMaxBytes, types.MaxQueryBytes))),
+			mcp.WithNumber("timeout_seconds", mcp.Description(fmt.Sprintf("Statement timeout in seconds (default: %d, max: %d)",
+				int(types.DefaultQueryTimeout.Seconds()), int(types.MaxQueryTimeout.Seconds())))),
+		),
 	}
 }
 
@@ -67,6 +166,14 @@ func (h *ToolHandler) HandleTool(ctx context.Context, request mcp.CallToolReques
 		args = make(map[string]any)
 	}
 
+	// Record the calling session on the instances it creates
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		ctx = types.ContextWithSession(ctx, session.SessionID())
+	}
+
+	// Report the progress of long-running operations if the client asked for it
+	ctx = withProgress(ctx, request.Params.Meta)
+
 	switch name {
 	case "create_database_instance":
 		return h.handleCreateDatabaseInstance(ctx, args)
@@ -76,8 +183,38 @@ func (h *ToolHandler) HandleTool(ctx context.Context, request mcp.CallToolReques
 		return h.handleGetDatabaseInstance(ctx, args)
 	case "drop_database_instance":
 		return h.handleDropDatabaseInstance(ctx, args)
+	case "stop_database_instance":
+		return h.handleLifecycleOperation(ctx, args, "stop", h.manager.StopInstance)
+	case "start_database_instance":
+		return h.handleLifecycleOperation(ctx, args, "start", h.manager.StartInstance)
+	case "restart_database_instance":
+		return h.handleLifecycleOperation(ctx, args, "restart", h.manager.RestartInstance)
 	case "health_check_database":
 		return h.handleHealthCheckDatabase(ctx, args)
+	case "list_database_volumes":
+		return h.handleListDatabaseVolumes(ctx, args)
+	case "detach_database_instance":
+		return h.handleDetachDatabaseInstance(ctx, args)
+	case "attach_database_volume":
+		return h.handleAttachDatabaseVolume(ctx, args)
+	case "delete_database_volume":
+		return h.handleDeleteDatabaseVolume(ctx, args)
+	case "clone_database_instance":
+		return h.handleCloneDatabaseInstance(ctx, args)
+	case "snapshot_database_instance":
+		return h.handleSnapshotDatabaseInstance(ctx, args)
+	case "restore_database_instance":
+		return h.handleRestoreDatabaseInstance(ctx, args)
+	case "list_database_snapshots":
+		return h.handleListDatabaseSnapshots(ctx, args)
+	case "delete_database_snapshot":
+		return h.handleDeleteDatabaseSnapshot(ctx, args)
+	case "exec_in_instance":
+		return h.handleExecInInstance(ctx, args)
+	case "get_instance_logs":
+		return h.handleGetInstanceLogs(ctx, args)
+	case "execute_sql":
+		return h.handleExecuteSQL(ctx, args)
 	default:
 		return mcp.NewToolResultError(fmt.Sprintf("Unknown tool: %s", name)), nil
 	}
@@ -103,11 +240,50 @@ func (h *ToolHandler) handleCreateDatabaseInstance(ctx context.Context, argument
 	if password, ok := arguments["password"].(string); ok {
 		opts.Password = password
 	}
+	if persistent, ok := arguments["persistent"].(bool); ok {
+		opts.Persistent = persistent
+	}
+	if ttl, ok := arguments["ttl"].(string); ok && ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid ttl %q: %v", ttl, err)), nil
+		}
+		opts.TTL = duration
+	}
+	if memory, ok := arguments["memory"].(string); ok && memory != "" {
+		size, err := units.RAMInBytes(memory)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid memory %q: %v", memory, err)), nil
+		}
+		opts.Memory = size
+	}
+	if cpus, ok := arguments["cpus"].(float64); ok {
+		opts.CPUs = cpus
+	}
+	if shmSize, ok := arguments["shm_size"].(string); ok && shmSize != "" {
+		size, err := units.RAMInBytes(shmSize)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid shm_size %q: %v", shmSize, err)), nil
+		}
+		opts.ShmSize = size
+	}
+	if tmpfs, ok := arguments["tmpfs"].(bool); ok {
+		opts.Tmpfs = tmpfs
+	}
+	if scripts, ok := arguments["init_scripts"].([]any); ok {
+		for i, script := range scripts {
+			value, ok := script.(string)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("init_scripts[%d] must be a string", i)), nil
+			}
+			opts.InitScripts = append(opts.InitScripts, value)
+		}
+	}
 
 	// Create instance
 	instance, err := h.manager.CreateInstance(ctx, opts)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create database instance: %v", err)), nil
+		return toolErrorResult("Failed to create database instance", err), nil
 	}
 
 	// Format response
@@ -124,6 +300,18 @@ func (h *ToolHandler) handleCreateDatabaseInstance(ctx context.Context, argument
 		"created_at":   instance.CreatedAt,
 		"status":       instance.Status,
 	}
+	if instance.ExpiresAt != nil {
+		response["expires_at"] = instance.ExpiresAt
+	}
+	if instance.Volume != "" {
+		response["volume"] = instance.Volume
+	}
+	if instance.Resources != nil {
+		response["resources"] = instance.Resources
+	}
+	if len(instance.InitScripts) > 0 {
+		response["init_scripts"] = instance.InitScripts
+	}
 
 	responseJSON, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
@@ -224,6 +412,38 @@ func (h *ToolHandler) handleDropDatabaseInstance(ctx context.Context, arguments
 	return mcp.NewToolResultText(fmt.Sprintf("Database instance dropped:\n\n```json\n%s\n```", string(responseJSON))), nil
 }
 
+// handleLifecycleOperation handles the stop, start and restart_database_instance tool calls.
+func (h *ToolHandler) handleLifecycleOperation(ctx context.Context, arguments map[string]any, action string,
+	operation func(ctx context.Context, id string) error) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	if err := operation(ctx, instanceID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to %s database instance: %v", action, err)), nil
+	}
+
+	instance, err := h.manager.GetInstance(ctx, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get database instance: %v", err)), nil
+	}
+
+	response := map[string]any{
+		"instance_id": instance.ID,
+		"type":        instance.Type,
+		"port":        instance.Port,
+		"status":      instance.Status,
+	}
+
+	responseJSON, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database instance after %s:\n\n```json\n%s\n```", action, string(responseJSON))), nil
+}
+
 // handleHealthCheckDatabase handles the health_check_database tool call.
 func (h *ToolHandler) handleHealthCheckDatabase(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
 	instanceID, ok := arguments["instance_id"].(string)
@@ -243,3 +463,351 @@ func (h *ToolHandler) handleHealthCheckDatabase(ctx context.Context, arguments m
 
 	return mcp.NewToolResultText(fmt.Sprintf("Health check results for instance %s:\n\n```json\n%s\n```", instanceID, string(responseJSON))), nil
 }
+
+// handleListDatabaseVolumes handles the list_database_volumes tool call.
+func (h *ToolHandler) handleListDatabaseVolumes(ctx context.Context, _ map[string]any) (*mcp.CallToolResult, error) {
+	volumes, err := h.manager.ListVolumes(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list database volumes: %v", err)), nil
+	}
+
+	if len(volumes) == 0 {
+		return mcp.NewToolResultText("No database volumes exist."), nil
+	}
+
+	response := map[string]any{
+		"count":   len(volumes),
+		"volumes": volumes,
+	}
+
+	responseJSON, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database volumes:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleDetachDatabaseInstance handles the detach_database_instance tool call.
+func (h *ToolHandler) handleDetachDatabaseInstance(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	volume, err := h.manager.DetachInstance(ctx, instanceID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to detach database instance: %v", err)), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(volume, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database instance detached, data volume kept:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleAttachDatabaseVolume handles the attach_database_volume tool call.
+func (h *ToolHandler) handleAttachDatabaseVolume(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	name, ok := arguments["volume"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("volume parameter is required"), nil
+	}
+
+	instance, err := h.manager.AttachVolume(ctx, name)
+	if err != nil {
+		return toolErrorResult("Failed to attach database volume", err), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(instance, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database volume attached:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleDeleteDatabaseVolume handles the delete_database_volume tool call.
+func (h *ToolHandler) handleDeleteDatabaseVolume(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	name, ok := arguments["volume"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("volume parameter is required"), nil
+	}
+
+	if err := h.manager.DeleteVolume(ctx, name); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete database volume: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database volume %s deleted.", name)), nil
+}
+
+// handleCloneDatabaseInstance handles the clone_database_instance tool call.
+func (h *ToolHandler) handleCloneDatabaseInstance(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	opts := types.CloneInstanceOptions{}
+	if persistent, ok := arguments["persistent"].(bool); ok {
+		opts.Persistent = persistent
+	}
+	if ttl, ok := arguments["ttl"].(string); ok && ttl != "" {
+		duration, err := time.ParseDuration(ttl)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid ttl %q: %v", ttl, err)), nil
+		}
+		opts.TTL = duration
+	}
+
+	clone, err := h.manager.CloneInstance(ctx, instanceID, opts)
+	if err != nil {
+		return toolErrorResult("Failed to clone database instance", err), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(clone, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database instance cloned:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleSnapshotDatabaseInstance handles the snapshot_database_instance tool call.
+func (h *ToolHandler) handleSnapshotDatabaseInstance(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	name, _ := arguments["name"].(string)
+
+	snapshot, err := h.manager.SnapshotInstance(ctx, instanceID, name)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to snapshot database instance: %v", err)), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database snapshot created:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleRestoreDatabaseInstance handles the restore_database_instance tool call.
+func (h *ToolHandler) handleRestoreDatabaseInstance(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	snapshotID, ok := arguments["snapshot_id"].(string)
+	if !ok || snapshotID == "" {
+		return mcp.NewToolResultError("snapshot_id parameter is required"), nil
+	}
+
+	targetID, _ := arguments["instance_id"].(string)
+
+	instance, err := h.manager.RestoreSnapshot(ctx, snapshotID, targetID)
+	if err != nil {
+		return toolErrorResult("Failed to restore database snapshot", err), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(instance, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database snapshot restored into instance %s:\n\n```json\n%s\n```", instance.ID, string(responseJSON))), nil
+}
+
+// handleListDatabaseSnapshots handles the list_database_snapshots tool call.
+func (h *ToolHandler) handleListDatabaseSnapshots(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	snapshots, err := h.manager.ListSnapshots(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list database snapshots: %v", err)), nil
+	}
+
+	if instanceID, _ := arguments["instance_id"].(string); instanceID != "" {
+		filtered := make([]*types.Snapshot, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			if snapshot.InstanceID == instanceID {
+				filtered = append(filtered, snapshot)
+			}
+		}
+		snapshots = filtered
+	}
+
+	if len(snapshots) == 0 {
+		return mcp.NewToolResultText("No database snapshots exist."), nil
+	}
+
+	response := map[string]any{
+		"count":     len(snapshots),
+		"snapshots": snapshots,
+	}
+
+	responseJSON, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database snapshots:\n\n```json\n%s\n```", string(responseJSON))), nil
+}
+
+// handleDeleteDatabaseSnapshot handles the delete_database_snapshot tool call.
+func (h *ToolHandler) handleDeleteDatabaseSnapshot(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	snapshotID, ok := arguments["snapshot_id"].(string)
+	if !ok || snapshotID == "" {
+		return mcp.NewToolResultError("snapshot_id parameter is required"), nil
+	}
+
+	snapshot, err := h.manager.DeleteSnapshot(ctx, snapshotID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete database snapshot: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Database snapshot %s deleted.", snapshot.ID)), nil
+}
+
+// handleExecInInstance handles the exec_in_instance tool call.
+func (h *ToolHandler) handleExecInInstance(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	command, ok := arguments["command"].([]any)
+	if !ok || len(command) == 0 {
+		return mcp.NewToolResultError("command parameter is required"), nil
+	}
+
+	opts := types.ExecOptions{}
+	for i, arg := range command {
+		value, ok := arg.(string)
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("command[%d] must be a string", i)), nil
+		}
+		opts.Cmd = append(opts.Cmd, value)
+	}
+	if stdin, ok := arguments["stdin"].(string); ok && stdin != "" {
+		opts.Stdin = strings.NewReader(stdin)
+	}
+	if timeout, ok := arguments["timeout_seconds"].(float64); ok {
+		opts.Timeout = time.Duration(timeout * float64(time.Second))
+	}
+	maxOutputBytes := 0
+	if maxOutput, ok := arguments["max_output_bytes"].(float64); ok {
+		maxOutputBytes = int(maxOutput)
+	}
+
+	result, err := h.manager.RunInInstance(ctx, instanceID, opts, maxOutputBytes)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to run command: %v", err)), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Command exited with code %d:\n\n```json\n%s\n```", result.ExitCode, string(responseJSON))), nil
+}
+
+// handleGetInstanceLogs handles the get_instance_logs tool call.
+func (h *ToolHandler) handleGetInstanceLogs(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	filter := types.LogFilter{}
+	if tail, ok := arguments["tail"].(float64); ok {
+		filter.Tail = int(tail)
+	}
+	if since, ok := arguments["since"].(string); ok {
+		filter.Since = since
+	}
+	if level, ok := arguments["level"].(string); ok {
+		filter.Level = level
+	}
+	if pattern, ok := arguments["pattern"].(string); ok {
+		filter.Pattern = pattern
+	}
+	if timestamps, ok := arguments["timestamps"].(bool); ok {
+		filter.Timestamps = timestamps
+	}
+
+	result, err := h.manager.GetLogs(ctx, instanceID, filter)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get instance logs: %v", err)), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Found %d log lines:\n\n```json\n%s\n```", len(result.Lines), string(responseJSON))), nil
+}
+
+// handleExecuteSQL handles the execute_sql tool call.
+func (h *ToolHandler) handleExecuteSQL(ctx context.Context, arguments map[string]any) (*mcp.CallToolResult, error) {
+	instanceID, ok := arguments["instance_id"].(string)
+	if !ok || instanceID == "" {
+		return mcp.NewToolResultError("instance_id parameter is required"), nil
+	}
+
+	query, ok := arguments["sql"].(string)
+	if !ok || query == "" {
+		return mcp.NewToolResultError("sql parameter is required"), nil
+	}
+
+	opts := types.QueryOptions{}
+	if params, ok := arguments["params"].([]any); ok {
+		opts.Params = params
+	}
+	if maxRows, ok := arguments["max_rows"].(float64); ok {
+		opts.MaxRows = int(maxRows)
+	}
+	if maxBytes, ok := arguments["max_bytes"].(float64); ok {
+		opts.MaxBytes = int(maxBytes)
+	}
+	if timeout, ok := arguments["timeout_seconds"].(float64); ok {
+		opts.Timeout = time.Duration(timeout * float64(time.Second))
+	}
+
+	result, err := h.manager.ExecuteSQL(ctx, instanceID, query, opts)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to execute SQL: %v", err)), nil
+	}
+
+	responseJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format response: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("SQL executed on instance %s:\n\n```json\n%s\n```", instanceID, string(responseJSON))), nil
+}
+
+// quotaErrorResult is the structured content of a tool error caused by an exceeded quota.
+type quotaErrorResult struct {
+	Error string `json:"error"`
+	*types.QuotaError
+	Hint string `json:"hint"`
+}
+
+// toolErrorResult returns a tool error result for err. Quota errors also carry the quota as
+// structured content and tell the agent to drop an instance first.
+func toolErrorResult(message string, err error) *mcp.CallToolResult {
+	var quotaErr *types.QuotaError
+	if !errors.As(err, &quotaErr) {
+		return mcp.NewToolResultError(fmt.Sprintf("%s: %v", message, err))
+	}
+
+	hint := "Drop an instance you no longer need with drop_database_instance (see list_database_instances) and try again."
+	if quotaErr.Quota == types.QuotaMaxTotalMemory {
+		hint = "Drop or stop an instance you no longer need (see list_database_instances), or request less memory, and try again."
+	}
+
+	result := mcp.NewToolResultError(fmt.Sprintf("%s: %v. %s", message, err, hint))
+	result.StructuredContent = quotaErrorResult{Error: "quota_exceeded", QuotaError: quotaErr, Hint: hint}
+	return result
+}
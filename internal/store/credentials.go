@@ -0,0 +1,410 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	credentialsFile = "credentials.enc"
+	keyFile         = "credentials.key"
+	stagedKeyFile   = keyFile + ".new"
+	lockFileName    = "credentials.lock"
+	keySize         = 32 // AES-256
+)
+
+// credentialEntry is a single stored credential.
+type credentialEntry struct {
+	Password  string    `json:"password"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// encryptedFile is the on-disk representation of the credential store.
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// CredentialStore persists instance passwords in an AES-GCM encrypted file keyed by instance ID.
+// The encryption key is kept in a separate file readable only by the current user.
+// Every operation holds an exclusive lock on a lock file in the store directory, so the
+// server and concurrent CLI invocations never overwrite each other's entries.
+type CredentialStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewCredentialStore creates a credential store rooted at the given directory.
+// Files are created lazily on the first write.
+func NewCredentialStore(dir string) *CredentialStore {
+	return &CredentialStore{dir: dir}
+}
+
+// NewDefaultCredentialStore creates a credential store in the default storage directory.
+func NewDefaultCredentialStore() (*CredentialStore, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewCredentialStore(dir), nil
+}
+
+// Dir returns the directory backing the store.
+func (s *CredentialStore) Dir() string {
+	return s.dir
+}
+
+// SavePassword stores the password for an instance, replacing any existing entry.
+func (s *CredentialStore) SavePassword(instanceID, password string) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	key, err := s.loadOrCreateKey()
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.load(key)
+	if err != nil {
+		return err
+	}
+
+	entries[instanceID] = credentialEntry{Password: password, UpdatedAt: time.Now().UTC()}
+	return s.save(key, entries)
+}
+
+// LoadPassword returns the stored password for an instance, or an empty string if none is stored.
+func (s *CredentialStore) LoadPassword(instanceID string) (string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	key, err := s.loadKey()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	entries, err := s.load(key)
+	if err != nil {
+		return "", err
+	}
+
+	return entries[instanceID].Password, nil
+}
+
+// DeletePassword removes the stored password for an instance. Deleting a missing entry is not an error.
+func (s *CredentialStore) DeletePassword(instanceID string) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	key, err := s.loadKey()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	entries, err := s.load(key)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := entries[instanceID]; !exists {
+		return nil
+	}
+
+	delete(entries, instanceID)
+	return s.save(key, entries)
+}
+
+// InstanceIDs returns the IDs of all instances with stored credentials.
+func (s *CredentialStore) InstanceIDs() ([]string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	key, err := s.loadKey()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries, err := s.load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Prune removes all entries whose instance ID is not in keep and returns the removed IDs.
+func (s *CredentialStore) Prune(keep map[string]bool) ([]string, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	key, err := s.loadKey()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries, err := s.load(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for id := range entries {
+		if !keep[id] {
+			delete(entries, id)
+			removed = append(removed, id)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil, nil
+	}
+
+	return removed, s.save(key, entries)
+}
+
+// RotateKey generates a new encryption key and re-encrypts all stored entries with it.
+func (s *CredentialStore) RotateKey() error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	oldKey, err := s.loadOrCreateKey()
+	if err != nil {
+		return err
+	}
+
+	entries, err := s.load(oldKey)
+	if err != nil {
+		return err
+	}
+
+	newKey := make([]byte, keySize)
+	if _, err := rand.Read(newKey); err != nil {
+		return fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+
+	// Stage the new key before re-encrypting, so that an interruption between writing
+	// the data and swapping the key never leaves the data without its key on disk;
+	// loadKey finishes the swap if it finds the data encrypted with the staged key.
+	staged := filepath.Join(s.dir, stagedKeyFile)
+	if err := writeFileAtomic(staged, newKey, 0o600); err != nil {
+		return fmt.Errorf("failed to write encryption key: %w", err)
+	}
+	if err := s.save(newKey, entries); err != nil {
+		os.Remove(staged)
+		return err
+	}
+	if err := os.Rename(staged, filepath.Join(s.dir, keyFile)); err != nil {
+		return fmt.Errorf("failed to replace encryption key: %w", err)
+	}
+
+	return nil
+}
+
+// lock serializes access to the store within this process and, through the lock file,
+// across processes. The returned function releases both locks.
+func (s *CredentialStore) lock() (func(), error) {
+	s.mu.Lock()
+
+	unlock, err := lockFile(filepath.Join(s.dir, lockFileName))
+	if err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("failed to lock credential store: %w", err)
+	}
+
+	return func() {
+		unlock()
+		s.mu.Unlock()
+	}, nil
+}
+
+// loadKey reads the encryption key from disk, completing a key rotation that was
+// interrupted after the data was re-encrypted but before the staged key was swapped in.
+func (s *CredentialStore) loadKey() ([]byte, error) {
+	key, err := readKey(filepath.Join(s.dir, keyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	staged := filepath.Join(s.dir, stagedKeyFile)
+	if _, err := os.Stat(staged); err != nil {
+		return key, nil
+	}
+
+	if _, err := s.load(key); err == nil {
+		// The rotation was interrupted before the data was re-encrypted; the staged key is unused.
+		os.Remove(staged)
+		return key, nil
+	}
+
+	stagedKey, err := readKey(staged)
+	if err != nil {
+		return key, nil
+	}
+	if _, err := s.load(stagedKey); err != nil {
+		return key, nil
+	}
+	if err := os.Rename(staged, filepath.Join(s.dir, keyFile)); err != nil {
+		return nil, fmt.Errorf("failed to replace encryption key: %w", err)
+	}
+
+	return stagedKey, nil
+}
+
+// readKey reads and validates an encryption key file.
+func readKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid credential store key: expected %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
+
+// loadOrCreateKey reads the encryption key from disk, generating it if it does not exist yet.
+func (s *CredentialStore) loadOrCreateKey() ([]byte, error) {
+	key, err := s.loadKey()
+	if err == nil {
+		return key, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read credential store key: %w", err)
+	}
+
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, keyFile), key, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write encryption key: %w", err)
+	}
+
+	return key, nil
+}
+
+// load decrypts and returns all stored entries.
+func (s *CredentialStore) load(key []byte) (map[string]credentialEntry, error) {
+	entries := make(map[string]credentialEntry)
+
+	raw, err := os.ReadFile(filepath.Join(s.dir, credentialsFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read credential store: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse credential store: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential store: %w", err)
+	}
+
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse credential store entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// save encrypts and writes all entries to disk.
+func (s *CredentialStore) save(key []byte, entries map[string]credentialEntry) error {
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode credential store entries: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	raw, err := json.Marshal(encryptedFile{
+		Version: 1,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode credential store: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.dir, credentialsFile), raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write credential store: %w", err)
+	}
+
+	return nil
+}
+
+// newGCM creates an AES-GCM cipher for the given key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
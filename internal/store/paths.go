@@ -0,0 +1,84 @@
+// Package store provides local, file-based persistence for data that must
+// outlive a single server or CLI process.
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ConfigDirEnv is the environment variable that overrides the default storage directory.
+const ConfigDirEnv = "DEV_POSTGRES_MCP_CONFIG_DIR"
+
+// DefaultDir returns the directory used for local storage.
+// It honours DEV_POSTGRES_MCP_CONFIG_DIR and falls back to the user config directory.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(ConfigDirEnv); dir != "" {
+		return dir, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user config directory: %w", err)
+	}
+
+	return filepath.Join(configDir, "dev-postgres-mcp"), nil
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place,
+// so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions on temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// lockFile takes an exclusive lock on the file at path, creating it if needed, so that
+// read-modify-write cycles are serialized across processes sharing the same directory.
+// The returned function releases the lock.
+func lockFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+	if err := lockFileHandle(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+
+	return func() {
+		unlockFileHandle(f)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+const (
+	snapshotsDir     = "snapshots"
+	snapshotMetaFile = "metadata.json"
+	snapshotDataFile = "dump.sql"
+)
+
+// SnapshotStore keeps instance snapshots on disk, one directory per snapshot holding
+// the snapshot metadata and the dump itself.
+type SnapshotStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewSnapshotStore creates a snapshot store rooted at the given directory.
+// Snapshots are kept in its "snapshots" subdirectory, which is created lazily.
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{dir: filepath.Join(dir, snapshotsDir)}
+}
+
+// NewDefaultSnapshotStore creates a snapshot store in the default storage directory.
+func NewDefaultSnapshotStore() (*SnapshotStore, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshotStore(dir), nil
+}
+
+// Dir returns the directory holding the snapshots.
+func (s *SnapshotStore) Dir() string {
+	return s.dir
+}
+
+// Create stores a new snapshot. The write function is called with the destination for the
+// snapshot data; if it fails, nothing is stored. The snapshot's Size is filled in.
+func (s *SnapshotStore) Create(snapshot *types.Snapshot, write func(w io.Writer) error) error {
+	if snapshot.ID == "" || strings.ContainsAny(snapshot.ID, `/\.`) {
+		return fmt.Errorf("invalid snapshot ID %q", snapshot.ID)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	// Write into a staging directory so incomplete snapshots are never listed
+	staging, err := os.MkdirTemp(s.dir, ".tmp-"+snapshot.ID+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	file, err := os.OpenFile(filepath.Join(staging, snapshotDataFile), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	counter := &countingWriter{w: file}
+	if err := write(counter); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+	snapshot.Size = counter.n
+
+	metadata, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, snapshotMetaFile), metadata, 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot metadata: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Rename(staging, filepath.Join(s.dir, snapshot.ID)); err != nil {
+		return fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the metadata of a snapshot by ID or unique ID prefix.
+func (s *SnapshotStore) Get(id string) (*types.Snapshot, error) {
+	snapshots, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return MatchSnapshot(snapshots, id)
+}
+
+// MatchSnapshot returns the snapshot among snapshots with the given ID or unique ID prefix.
+func MatchSnapshot(snapshots []*types.Snapshot, id string) (*types.Snapshot, error) {
+	var matches []*types.Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.ID == id {
+			return snapshot, nil
+		}
+		if strings.HasPrefix(snapshot.ID, id) {
+			matches = append(matches, snapshot)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("snapshot %s not found", id)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("multiple snapshots match %s", id)
+	}
+}
+
+// Open returns a reader for the data of a snapshot. The caller must close it.
+func (s *SnapshotStore) Open(id string) (io.ReadCloser, error) {
+	snapshot, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, snapshot.ID, snapshotDataFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot %s: %w", snapshot.ID, err)
+	}
+
+	return file, nil
+}
+
+// List returns all stored snapshots, oldest first.
+func (s *SnapshotStore) List() ([]*types.Snapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	var snapshots []*types.Snapshot
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(s.dir, entry.Name(), snapshotMetaFile))
+		if err != nil {
+			continue
+		}
+
+		var snapshot types.Snapshot
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata of snapshot %s: %w", entry.Name(), err)
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt)
+	})
+
+	return snapshots, nil
+}
+
+// Delete removes a snapshot by ID or unique ID prefix and returns its metadata.
+func (s *SnapshotStore) Delete(id string) (*types.Snapshot, error) {
+	snapshot, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.RemoveAll(filepath.Join(s.dir, snapshot.ID)); err != nil {
+		return nil, fmt.Errorf("failed to delete snapshot %s: %w", snapshot.ID, err)
+	}
+
+	return snapshot, nil
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
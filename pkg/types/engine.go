@@ -0,0 +1,528 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// EngineDescriptor describes a database engine that instances can be created for. String
+// fields documented as templates are Go text/template strings rendered with the instance's
+// Database, Username and Password (and Port, for host-side commands).
+type EngineDescriptor struct {
+	// Type identifies the engine. It is used in container, volume and label names and must
+	// consist of lowercase letters, digits and dashes.
+	Type DatabaseType
+
+	// DisplayName is the human-readable engine name (defaults to Type).
+	DisplayName string
+
+	// Image is the Docker image repository; instances use Image:<version>.
+	Image string
+
+	// Port is the port the engine listens on inside the container.
+	Port int
+
+	// DefaultVersion is the image tag used when no version is requested.
+	DefaultVersion string
+
+	// DefaultDatabase is the database name used when none is requested.
+	DefaultDatabase string
+
+	// DefaultUsername is the username used when none is requested.
+	DefaultUsername string
+
+	// FixedUsername rejects requested usernames other than DefaultUsername, for engines whose
+	// images only set up that user.
+	FixedUsername bool
+
+	// Environment holds the container's environment variable templates.
+	Environment map[string]string
+
+	// Command overrides the image's default command with these templates (optional).
+	Command []string
+
+	// HealthCheck is the Docker health check command template (e.g. ["CMD-SHELL", "..."]).
+	HealthCheck []string
+
+	// DataDir is the data directory inside the container, used for persistent volumes and
+	// tmpfs data directories.
+	DataDir string
+
+	// MemoryLimit is the memory limit in bytes of instances that do not request one. It takes
+	// precedence over a smaller server default (zero uses the server default).
+	MemoryLimit int64
+
+	// StrongPassword requires passwords of at least 8 characters mixing upper and lower case
+	// letters, digits and symbols (at least three of the four), as SQL Server does.
+	StrongPassword bool
+
+	// SetupCommand is a command template run inside the container once the instance is healthy,
+	// before init scripts, e.g. to create the requested database. Instances of engines with a
+	// setup command cannot be created without waiting for them to become healthy.
+	SetupCommand []string
+
+	// BuildDSN returns the connection string of an instance. It is also used to connect
+	// with DriverName, so it must be in the format that driver expects.
+	BuildDSN func(instance *DatabaseInstance) string
+
+	// DriverName is the database/sql driver used to connect to instances (empty disables
+	// SQL execution and connection health checks). The driver must be registered by the program.
+	DriverName string
+
+	// ConnectionsQuery returns the number of other client connections.
+	ConnectionsQuery string
+
+	// VersionQuery returns the server version.
+	VersionQuery string
+
+	// UptimeQuery returns the server uptime in seconds in its last column.
+	UptimeQuery string
+
+	// ColumnsQuery returns the table name, column name, data type and nullability ("YES" or
+	// "NO") of the columns of the tables in the instance's database, ordered by table and
+	// column position.
+	ColumnsQuery string
+
+	// ClientEnvironment holds environment variable templates for client tools run inside the container.
+	ClientEnvironment map[string]string
+
+	// DumpCommand is a command template writing a logical dump of the database to stdout.
+	DumpCommand []string
+
+	// RestoreCommand is a command template applying a script read from stdin to the database
+	// (SQL, or the engine's own command language). It is used for restores and init scripts.
+	RestoreCommand []string
+
+	// ShellCommand is a command template for an interactive client inside the container.
+	ShellCommand []string
+
+	// HostShellCommand is a command template for an interactive client on the host.
+	HostShellCommand []string
+}
+
+// DefaultMemoryLimit is the container memory limit of instances when neither the request, the
+// server configuration nor the engine sets one.
+const DefaultMemoryLimit = 512 * 1024 * 1024
+
+// EngineCapabilities reports which optional features an engine supports.
+type EngineCapabilities struct {
+	SQL         bool `json:"sql"`
+	Snapshots   bool `json:"snapshots"`
+	InitScripts bool `json:"init_scripts"`
+	Shell       bool `json:"shell"`
+	Persistent  bool `json:"persistent"`
+}
+
+// Capabilities reports which optional features the engine supports, based on the fields set.
+func (d EngineDescriptor) Capabilities() EngineCapabilities {
+	return EngineCapabilities{
+		SQL:         d.DriverName != "",
+		Snapshots:   len(d.DumpCommand) > 0 && len(d.RestoreCommand) > 0,
+		InitScripts: len(d.RestoreCommand) > 0,
+		Shell:       len(d.ShellCommand) > 0,
+		Persistent:  d.DataDir != "",
+	}
+}
+
+// validEngineType matches engine types that are safe in container, volume and label names.
+var validEngineType = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// Validate checks that the descriptor has the fields required to create instances.
+func (d EngineDescriptor) Validate() error {
+	switch {
+	case !validEngineType.MatchString(string(d.Type)):
+		return fmt.Errorf("invalid engine type %q: must consist of lowercase letters, digits and dashes", d.Type)
+	case d.Image == "":
+		return fmt.Errorf("engine %s: image is required", d.Type)
+	case d.Port <= 0 || d.Port > 65535:
+		return fmt.Errorf("engine %s: invalid port %d", d.Type, d.Port)
+	case d.DefaultVersion == "":
+		return fmt.Errorf("engine %s: default version is required", d.Type)
+	case d.BuildDSN == nil:
+		return fmt.Errorf("engine %s: DSN builder is required", d.Type)
+	case d.MemoryLimit < 0:
+		return fmt.Errorf("engine %s: memory limit must not be negative", d.Type)
+	}
+	return nil
+}
+
+// engineRegistry holds the registered engine descriptors.
+type engineRegistry struct {
+	mu      sync.RWMutex
+	engines map[DatabaseType]EngineDescriptor
+}
+
+// engines is the process-wide engine registry, preloaded with the built-in engines.
+var engines = newEngineRegistry(builtinEngines())
+
+// newEngineRegistry creates a registry holding the given descriptors.
+func newEngineRegistry(descriptors []EngineDescriptor) *engineRegistry {
+	r := &engineRegistry{engines: make(map[DatabaseType]EngineDescriptor, len(descriptors))}
+	for _, d := range descriptors {
+		if err := r.register(d); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// register adds a descriptor, failing if it is invalid or its type is already registered.
+func (r *engineRegistry) register(d EngineDescriptor) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if d.DisplayName == "" {
+		d.DisplayName = string(d.Type)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.engines[d.Type]; exists {
+		return fmt.Errorf("engine %s is already registered", d.Type)
+	}
+	r.engines[d.Type] = d
+	return nil
+}
+
+// RegisterEngine adds an engine to the registry. Engines must be registered before the
+// database managers are created; instances can then be created for the new type like for
+// the built-in ones.
+func RegisterEngine(d EngineDescriptor) error {
+	return engines.register(d)
+}
+
+// LookupEngine returns the descriptor of a registered engine.
+func LookupEngine(dbType DatabaseType) (EngineDescriptor, bool) {
+	engines.mu.RLock()
+	defer engines.mu.RUnlock()
+
+	d, ok := engines.engines[dbType]
+	return d, ok
+}
+
+// Engines returns the descriptors of all registered engines, sorted by type.
+func Engines() []EngineDescriptor {
+	engines.mu.RLock()
+	defer engines.mu.RUnlock()
+
+	descriptors := make([]EngineDescriptor, 0, len(engines.engines))
+	for _, d := range engines.engines {
+		descriptors = append(descriptors, d)
+	}
+	slices.SortFunc(descriptors, func(a, b EngineDescriptor) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+	return descriptors
+}
+
+// EngineTypes returns the types of all registered engines, sorted.
+func EngineTypes() []DatabaseType {
+	descriptors := Engines()
+	dbTypes := make([]DatabaseType, len(descriptors))
+	for i, d := range descriptors {
+		dbTypes[i] = d.Type
+	}
+	return dbTypes
+}
+
+// EngineTypeNames returns the names of all registered engine types, sorted.
+func EngineTypeNames() []string {
+	dbTypes := EngineTypes()
+	names := make([]string, len(dbTypes))
+	for i, dbType := range dbTypes {
+		names[i] = string(dbType)
+	}
+	return names
+}
+
+// postgresDSN builds a lib/pq connection URL.
+func postgresDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("postgres://%s:%s@localhost:%d/%s?sslmode=disable",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// mysqlDSN builds a go-sql-driver/mysql DSN.
+func mysqlDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("%s:%s@tcp(localhost:%d)/%s",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// redisDSN builds a Redis URL selecting the instance's numeric database.
+func redisDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("redis://%s:%s@localhost:%d/%s",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// mongoDBDSN builds a MongoDB connection string authenticating against the admin database.
+func mongoDBDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("mongodb://%s:%s@localhost:%d/%s?authSource=admin",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// clickHouseDSN builds a ClickHouse native protocol URL.
+func clickHouseDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("clickhouse://%s:%s@localhost:%d/%s",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// sqlServerDSN builds a go-mssqldb connection URL. No SQL Server driver is registered, so
+// execute_sql is not supported for SQL Server instances; the DSN is only handed to clients.
+func sqlServerDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("sqlserver://%s:%s@localhost:%d?database=%s",
+		instance.Username, instance.Password, instance.Port, instance.Database)
+}
+
+// cockroachDBDSN builds a PostgreSQL wire protocol URL for an insecure CockroachDB node,
+// which does not use passwords.
+func cockroachDBDSN(instance *DatabaseInstance) string {
+	return fmt.Sprintf("postgres://%s@localhost:%d/%s?sslmode=disable",
+		instance.Username, instance.Port, instance.Database)
+}
+
+// sqlcmd is the path of the sqlcmd client in the SQL Server images.
+const sqlcmd = "/opt/mssql-tools18/bin/sqlcmd"
+
+// redisEngine returns the descriptor of Redis or a Redis-compatible server whose binaries
+// are named <cli>-server and <cli>-cli.
+func redisEngine(dbType DatabaseType, displayName, image, defaultVersion, cli string) EngineDescriptor {
+	return EngineDescriptor{
+		Type:            dbType,
+		DisplayName:     displayName,
+		Image:           image,
+		Port:            6379,
+		DefaultVersion:  defaultVersion,
+		DefaultDatabase: "0",
+		DefaultUsername: "default",
+		Command:         []string{cli + "-server", "--requirepass", "{{.Password}}", "--appendonly", "yes"},
+		HealthCheck:     []string{"CMD-SHELL", "REDISCLI_AUTH={{.Password}} " + cli + "-cli -h 127.0.0.1 ping | grep -q PONG"},
+		DataDir:         "/data",
+		BuildDSN:        redisDSN,
+		ClientEnvironment: map[string]string{
+			"REDISCLI_AUTH":  "{{.Password}}",
+			"VALKEYCLI_AUTH": "{{.Password}}",
+		},
+		RestoreCommand:   []string{cli + "-cli", "-n", "{{.Database}}"},
+		ShellCommand:     []string{cli + "-cli", "-n", "{{.Database}}"},
+		HostShellCommand: []string{cli + "-cli", "-h", "127.0.0.1", "-p", "{{.Port}}", "-n", "{{.Database}}"},
+	}
+}
+
+// mysqlConnectionsQuery counts client connections on MySQL and MariaDB.
+const mysqlConnectionsQuery = "SELECT COUNT(*) FROM information_schema.PROCESSLIST WHERE ID <> CONNECTION_ID() AND COMMAND <> 'Daemon' AND USER <> 'system user'"
+
+// postgresColumnsQuery lists the columns of the tables in the current schema on PostgreSQL
+// and CockroachDB.
+const postgresColumnsQuery = "SELECT c.table_name, c.column_name, c.data_type, c.is_nullable FROM information_schema.columns c " +
+	"JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name " +
+	"WHERE c.table_schema = current_schema() AND t.table_type = 'BASE TABLE' ORDER BY c.table_name, c.ordinal_position"
+
+// mysqlColumnsQuery lists the columns of the tables in the current database on MySQL and MariaDB.
+const mysqlColumnsQuery = "SELECT c.TABLE_NAME, c.COLUMN_NAME, c.COLUMN_TYPE, c.IS_NULLABLE FROM information_schema.COLUMNS c " +
+	"JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME " +
+	"WHERE c.TABLE_SCHEMA = DATABASE() AND t.TABLE_TYPE = 'BASE TABLE' ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION"
+
+// mysqlPrivilegeTables are the tables of the mysql system schema that hold accounts and
+// privileges. They are left out of dumps, so that restoring a dump of the default mysql
+// database does not replace the target's accounts and root password with the source's.
+var mysqlPrivilegeTables = []string{
+	"user", "global_priv", "db", "tables_priv", "columns_priv", "procs_priv",
+	"proxies_priv", "role_edges", "default_roles", "password_history", "roles_mapping",
+}
+
+// mysqlDumpCommand returns the dump command of MySQL-compatible engines.
+func mysqlDumpCommand(tool string) []string {
+	cmd := []string{tool, "-u", "{{.Username}}", "--single-transaction", "--routines", "--triggers"}
+	for _, table := range mysqlPrivilegeTables {
+		cmd = append(cmd, "--ignore-table=mysql."+table)
+	}
+	return append(cmd, "{{.Database}}")
+}
+
+// builtinEngines returns the descriptors of the engines supported out of the box.
+func builtinEngines() []EngineDescriptor {
+	return []EngineDescriptor{
+		{
+			Type:            DatabaseTypePostgreSQL,
+			DisplayName:     "PostgreSQL",
+			Image:           "postgres",
+			Port:            5432,
+			DefaultVersion:  "17",
+			DefaultDatabase: "postgres",
+			DefaultUsername: "postgres",
+			Environment: map[string]string{
+				"POSTGRES_DB":       "{{.Database}}",
+				"POSTGRES_USER":     "{{.Username}}",
+				"POSTGRES_PASSWORD": "{{.Password}}",
+			},
+			HealthCheck:       []string{"CMD-SHELL", "pg_isready -h 127.0.0.1 -U {{.Username}} -d {{.Database}}"},
+			DataDir:           "/var/lib/postgresql/data",
+			BuildDSN:          postgresDSN,
+			DriverName:        "postgres",
+			ConnectionsQuery:  "SELECT count(*) FROM pg_stat_activity WHERE backend_type = 'client backend' AND pid <> pg_backend_pid()",
+			VersionQuery:      "SHOW server_version",
+			UptimeQuery:       "SELECT CAST(EXTRACT(EPOCH FROM now() - pg_postmaster_start_time()) AS bigint)",
+			ColumnsQuery:      postgresColumnsQuery,
+			ClientEnvironment: map[string]string{"PGPASSWORD": "{{.Password}}"},
+			DumpCommand:       []string{"pg_dump", "-U", "{{.Username}}", "-d", "{{.Database}}", "--clean", "--if-exists", "--no-owner"},
+			RestoreCommand:    []string{"psql", "-q", "-U", "{{.Username}}", "-d", "{{.Database}}", "-v", "ON_ERROR_STOP=1"},
+			ShellCommand:      []string{"psql", "-U", "{{.Username}}", "-d", "{{.Database}}"},
+			HostShellCommand:  []string{"psql", "-h", "127.0.0.1", "-p", "{{.Port}}", "-U", "{{.Username}}", "-d", "{{.Database}}"},
+		},
+		{
+			Type:            DatabaseTypeMySQL,
+			DisplayName:     "MySQL",
+			Image:           "mysql",
+			Port:            3306,
+			DefaultVersion:  "8.0",
+			DefaultDatabase: "mysql",
+			DefaultUsername: "root",
+			Environment: map[string]string{
+				"MYSQL_DATABASE":      "{{.Database}}",
+				"MYSQL_ROOT_PASSWORD": "{{.Password}}",
+			},
+			HealthCheck:       []string{"CMD-SHELL", "mysqladmin ping -h 127.0.0.1 -u root -p{{.Password}} --silent"},
+			DataDir:           "/var/lib/mysql",
+			BuildDSN:          mysqlDSN,
+			DriverName:        "mysql",
+			ConnectionsQuery:  mysqlConnectionsQuery,
+			VersionQuery:      "SELECT VERSION()",
+			UptimeQuery:       "SHOW GLOBAL STATUS LIKE 'Uptime'",
+			ColumnsQuery:      mysqlColumnsQuery,
+			ClientEnvironment: map[string]string{"MYSQL_PWD": "{{.Password}}"},
+			DumpCommand:       mysqlDumpCommand("mysqldump"),
+			RestoreCommand:    []string{"mysql", "-u", "{{.Username}}", "{{.Database}}"},
+			ShellCommand:      []string{"mysql", "-u", "{{.Username}}", "{{.Database}}"},
+			HostShellCommand:  []string{"mysql", "-h", "127.0.0.1", "-P", "{{.Port}}", "-u", "{{.Username}}", "{{.Database}}"},
+		},
+		{
+			Type:            DatabaseTypeMariaDB,
+			DisplayName:     "MariaDB",
+			Image:           "mariadb",
+			Port:            3306,
+			DefaultVersion:  "11",
+			DefaultDatabase: "mysql",
+			DefaultUsername: "root",
+			Environment: map[string]string{
+				"MARIADB_DATABASE":      "{{.Database}}",
+				"MARIADB_ROOT_PASSWORD": "{{.Password}}",
+			},
+			HealthCheck:       []string{"CMD-SHELL", "mariadb -h 127.0.0.1 -u root -p{{.Password}} -e 'SELECT 1' || mysqladmin ping -h 127.0.0.1 -u root -p{{.Password}}"},
+			DataDir:           "/var/lib/mysql",
+			BuildDSN:          mysqlDSN,
+			DriverName:        "mysql",
+			ConnectionsQuery:  mysqlConnectionsQuery,
+			VersionQuery:      "SELECT VERSION()",
+			UptimeQuery:       "SHOW GLOBAL STATUS LIKE 'Uptime'",
+			ColumnsQuery:      mysqlColumnsQuery,
+			ClientEnvironment: map[string]string{"MYSQL_PWD": "{{.Password}}"},
+			DumpCommand:       mysqlDumpCommand("mariadb-dump"),
+			RestoreCommand:    []string{"mariadb", "-u", "{{.Username}}", "{{.Database}}"},
+			ShellCommand:      []string{"mariadb", "-u", "{{.Username}}", "{{.Database}}"},
+			HostShellCommand:  []string{"mariadb", "-h", "127.0.0.1", "-P", "{{.Port}}", "-u", "{{.Username}}", "{{.Database}}"},
+		},
+		redisEngine(DatabaseTypeRedis, "Redis", "redis", "7", "redis"),
+		redisEngine(DatabaseTypeValkey, "Valkey", "valkey/valkey", "8", "valkey"),
+		{
+			Type:            DatabaseTypeMongoDB,
+			DisplayName:     "MongoDB",
+			Image:           "mongo",
+			Port:            27017,
+			DefaultVersion:  "8",
+			DefaultDatabase: "test",
+			DefaultUsername: "root",
+			Environment: map[string]string{
+				"MONGO_INITDB_ROOT_USERNAME": "{{.Username}}",
+				"MONGO_INITDB_ROOT_PASSWORD": "{{.Password}}",
+				"MONGO_INITDB_DATABASE":      "{{.Database}}",
+			},
+			HealthCheck: []string{"CMD-SHELL", "mongosh --quiet --host 127.0.0.1 -u {{.Username}} -p {{.Password}} --authenticationDatabase admin --eval 'quit(db.adminCommand({ping: 1}).ok ? 0 : 1)'"},
+			DataDir:     "/data/db",
+			BuildDSN:    mongoDBDSN,
+			RestoreCommand: []string{"mongosh", "--quiet", "-u", "{{.Username}}", "-p", "{{.Password}}",
+				"--authenticationDatabase", "admin", "{{.Database}}"},
+			ShellCommand: []string{"mongosh", "-u", "{{.Username}}", "-p", "{{.Password}}",
+				"--authenticationDatabase", "admin", "{{.Database}}"},
+			HostShellCommand: []string{"mongosh", "--host", "127.0.0.1", "--port", "{{.Port}}", "-u", "{{.Username}}", "-p", "{{.Password}}",
+				"--authenticationDatabase", "admin", "{{.Database}}"},
+		},
+		{
+			Type:            DatabaseTypeClickHouse,
+			DisplayName:     "ClickHouse",
+			Image:           "clickhouse/clickhouse-server",
+			Port:            9000,
+			DefaultVersion:  "24.8",
+			DefaultDatabase: "default",
+			DefaultUsername: "default",
+			Environment: map[string]string{
+				"CLICKHOUSE_DB":                        "{{.Database}}",
+				"CLICKHOUSE_USER":                      "{{.Username}}",
+				"CLICKHOUSE_PASSWORD":                  "{{.Password}}",
+				"CLICKHOUSE_DEFAULT_ACCESS_MANAGEMENT": "1",
+			},
+			HealthCheck: []string{"CMD-SHELL", "clickhouse-client --host 127.0.0.1 --user {{.Username}} --password {{.Password}} --query 'SELECT 1'"},
+			DataDir:     "/var/lib/clickhouse",
+			BuildDSN:    clickHouseDSN,
+			RestoreCommand: []string{"clickhouse-client", "--user", "{{.Username}}", "--password", "{{.Password}}",
+				"--database", "{{.Database}}", "--multiquery"},
+			ShellCommand: []string{"clickhouse-client", "--user", "{{.Username}}", "--password", "{{.Password}}",
+				"--database", "{{.Database}}"},
+			HostShellCommand: []string{"clickhouse-client", "--host", "127.0.0.1", "--port", "{{.Port}}", "--user", "{{.Username}}",
+				"--password", "{{.Password}}", "--database", "{{.Database}}"},
+		},
+		{
+			Type:            DatabaseTypeSQLServer,
+			DisplayName:     "SQL Server",
+			Image:           "mcr.microsoft.com/mssql/server",
+			Port:            1433,
+			DefaultVersion:  "2022-latest",
+			DefaultDatabase: "master",
+			DefaultUsername: "sa",
+			FixedUsername:   true,
+			Environment: map[string]string{
+				"ACCEPT_EULA":       "Y",
+				"MSSQL_PID":         "Developer",
+				"MSSQL_SA_PASSWORD": "{{.Password}}",
+			},
+			HealthCheck:    []string{"CMD-SHELL", sqlcmd + " -C -S 127.0.0.1 -U {{.Username}} -P \"$MSSQL_SA_PASSWORD\" -b -Q 'SELECT 1' -o /dev/null"},
+			DataDir:        "/var/opt/mssql",
+			MemoryLimit:    2 * 1024 * 1024 * 1024, // SQL Server refuses to start with less than 2 GB
+			StrongPassword: true,
+			SetupCommand: []string{sqlcmd, "-C", "-S", "127.0.0.1", "-U", "{{.Username}}", "-b",
+				"-Q", "IF DB_ID(N'{{.Database}}') IS NULL CREATE DATABASE [{{.Database}}]"},
+			BuildDSN:          sqlServerDSN,
+			ClientEnvironment: map[string]string{"SQLCMDPASSWORD": "{{.Password}}"},
+			RestoreCommand:    []string{sqlcmd, "-C", "-S", "127.0.0.1", "-U", "{{.Username}}", "-d", "{{.Database}}", "-b"},
+			ShellCommand:      []string{sqlcmd, "-C", "-S", "127.0.0.1", "-U", "{{.Username}}", "-d", "{{.Database}}"},
+			HostShellCommand:  []string{"sqlcmd", "-C", "-S", "127.0.0.1,{{.Port}}", "-U", "{{.Username}}", "-d", "{{.Database}}"},
+		},
+		{
+			Type:            DatabaseTypeCockroachDB,
+			DisplayName:     "CockroachDB",
+			Image:           "cockroachdb/cockroach",
+			Port:            26257,
+			DefaultVersion:  "latest-v24.3",
+			DefaultDatabase: "defaultdb",
+			DefaultUsername: "root",
+			FixedUsername:   true,
+			Environment:     map[string]string{"COCKROACH_DATABASE": "{{.Database}}"},
+			Command:         []string{"start-single-node", "--insecure"},
+			HealthCheck:     []string{"CMD-SHELL", "cockroach sql --insecure --host=127.0.0.1 -e 'SELECT 1'"},
+			DataDir:         "/cockroach/cockroach-data",
+			MemoryLimit:     1024 * 1024 * 1024,
+			BuildDSN:        cockroachDBDSN,
+			DriverName:      "postgres",
+			ConnectionsQuery: "SELECT count(*) FROM crdb_internal.cluster_sessions " +
+				"WHERE session_id <> (SELECT session_id FROM [SHOW session_id])",
+			VersionQuery:     "SELECT version()",
+			UptimeQuery:      "SELECT CAST(EXTRACT(EPOCH FROM now() - started_at) AS INT8) FROM crdb_internal.gossip_nodes LIMIT 1",
+			ColumnsQuery:     postgresColumnsQuery,
+			RestoreCommand:   []string{"cockroach", "sql", "--insecure", "--host=127.0.0.1", "-u", "{{.Username}}", "-d", "{{.Database}}"},
+			ShellCommand:     []string{"cockroach", "sql", "--insecure", "--host=127.0.0.1", "-u", "{{.Username}}", "-d", "{{.Database}}"},
+			HostShellCommand: []string{"psql", "-h", "127.0.0.1", "-p", "{{.Port}}", "-U", "{{.Username}}", "-d", "{{.Database}}"},
+		},
+	}
+}
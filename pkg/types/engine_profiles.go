@@ -0,0 +1,271 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"text/template"
+
+	"github.com/docker/go-units"
+	"gopkg.in/yaml.v3"
+)
+
+// EnginesConfigEnv is the environment variable naming an engine profiles file.
+const EnginesConfigEnv = "DEV_POSTGRES_MCP_ENGINES_CONFIG"
+
+// engineProfilesFile is the format of an engine profiles file (YAML or JSON).
+type engineProfilesFile struct {
+	Engines []engineProfile `yaml:"engines"`
+}
+
+// engineProfile declares a custom engine, or a variant of the engine named by Base whose
+// fields it inherits. Maps are merged into the base's maps; other set fields replace them.
+type engineProfile struct {
+	Type             string            `yaml:"type"`
+	Base             string            `yaml:"base"`
+	DisplayName      string            `yaml:"display_name"`
+	Image            string            `yaml:"image"`
+	Port             int               `yaml:"port"`
+	DefaultVersion   string            `yaml:"default_version"`
+	DefaultDatabase  string            `yaml:"default_database"`
+	DefaultUsername  string            `yaml:"default_username"`
+	Environment      map[string]string `yaml:"environment"`
+	Command          []string          `yaml:"command"`
+	HealthCheck      []string          `yaml:"health_check"`
+	DataDir          string            `yaml:"data_dir"`
+	MemoryLimit      string            `yaml:"memory_limit"`
+	StrongPassword   *bool             `yaml:"strong_password"`
+	FixedUsername    *bool             `yaml:"fixed_username"`
+	SetupCommand     []string          `yaml:"setup_command"`
+	DSN              string            `yaml:"dsn"`
+	Driver           string            `yaml:"driver"`
+	ConnectionsQuery string            `yaml:"connections_query"`
+	VersionQuery     string            `yaml:"version_query"`
+	UptimeQuery      string            `yaml:"uptime_query"`
+	ColumnsQuery     string            `yaml:"columns_query"`
+	ClientEnv        map[string]string `yaml:"client_environment"`
+	DumpCommand      []string          `yaml:"dump_command"`
+	RestoreCommand   []string          `yaml:"restore_command"`
+	ShellCommand     []string          `yaml:"shell_command"`
+	HostShellCommand []string          `yaml:"host_shell_command"`
+}
+
+// profileTemplateData is the data profile templates are checked against. It has the fields
+// available to the templates when they are rendered for an instance.
+type profileTemplateData struct {
+	Database string
+	Username string
+	Password string
+	Port     int
+}
+
+// LoadEngineProfiles reads engine profiles from a YAML or JSON file and returns their
+// validated descriptors. Profiles may be based on registered engines or on earlier profiles
+// in the same file. The descriptors are not registered.
+func LoadEngineProfiles(path string) ([]EngineDescriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read engine profiles: %w", err)
+	}
+
+	var file engineProfilesFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse engine profiles %s: %w", path, err)
+	}
+
+	descriptors := make([]EngineDescriptor, 0, len(file.Engines))
+	for i, profile := range file.Engines {
+		descriptor, err := profile.descriptor(descriptors)
+		if err != nil {
+			return nil, fmt.Errorf("engine profile %d in %s: %w", i+1, path, err)
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+
+	return descriptors, nil
+}
+
+// RegisterEngineProfiles loads the engine profiles in a file and registers them.
+func RegisterEngineProfiles(path string) error {
+	descriptors, err := LoadEngineProfiles(path)
+	if err != nil {
+		return err
+	}
+
+	for _, descriptor := range descriptors {
+		if err := RegisterEngine(descriptor); err != nil {
+			return fmt.Errorf("failed to register engine profile %s: %w", descriptor.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// descriptor builds the descriptor of a profile, resolving its base among the profiles
+// loaded so far and the registered engines.
+func (p engineProfile) descriptor(loaded []EngineDescriptor) (EngineDescriptor, error) {
+	var d EngineDescriptor
+	if p.Base != "" {
+		base, ok := findEngine(loaded, DatabaseType(p.Base))
+		if !ok {
+			return d, fmt.Errorf("unknown base engine %q", p.Base)
+		}
+		d = base
+		d.Environment = maps.Clone(base.Environment)
+		d.ClientEnvironment = maps.Clone(base.ClientEnvironment)
+	} else if p.DSN == "" {
+		return d, fmt.Errorf("engine %s: dsn template is required without a base engine", p.Type)
+	}
+
+	d.Type = DatabaseType(p.Type)
+	setIfNotEmpty(&d.DisplayName, p.DisplayName)
+	setIfNotEmpty(&d.Image, p.Image)
+	setIfNotEmpty(&d.DefaultVersion, p.DefaultVersion)
+	setIfNotEmpty(&d.DefaultDatabase, p.DefaultDatabase)
+	setIfNotEmpty(&d.DefaultUsername, p.DefaultUsername)
+	setIfNotEmpty(&d.DataDir, p.DataDir)
+	setIfNotEmpty(&d.DriverName, p.Driver)
+	setIfNotEmpty(&d.ConnectionsQuery, p.ConnectionsQuery)
+	setIfNotEmpty(&d.VersionQuery, p.VersionQuery)
+	setIfNotEmpty(&d.UptimeQuery, p.UptimeQuery)
+	setIfNotEmpty(&d.ColumnsQuery, p.ColumnsQuery)
+	if p.Port != 0 {
+		d.Port = p.Port
+	}
+	if p.MemoryLimit != "" {
+		memory, err := units.RAMInBytes(p.MemoryLimit)
+		if err != nil {
+			return d, fmt.Errorf("engine %s: invalid memory limit: %w", p.Type, err)
+		}
+		d.MemoryLimit = memory
+	}
+	if p.StrongPassword != nil {
+		d.StrongPassword = *p.StrongPassword
+	}
+	if p.FixedUsername != nil {
+		d.FixedUsername = *p.FixedUsername
+	}
+	d.Environment = mergeTemplates(d.Environment, p.Environment)
+	d.ClientEnvironment = mergeTemplates(d.ClientEnvironment, p.ClientEnv)
+	for _, list := range []struct {
+		dst *[]string
+		src []string
+	}{
+		{&d.Command, p.Command},
+		{&d.HealthCheck, p.HealthCheck},
+		{&d.SetupCommand, p.SetupCommand},
+		{&d.DumpCommand, p.DumpCommand},
+		{&d.RestoreCommand, p.RestoreCommand},
+		{&d.ShellCommand, p.ShellCommand},
+		{&d.HostShellCommand, p.HostShellCommand},
+	} {
+		if len(list.src) > 0 {
+			*list.dst = slices.Clone(list.src)
+		}
+	}
+
+	if p.DSN != "" {
+		dsn, err := template.New("dsn").Option("missingkey=error").Parse(p.DSN)
+		if err != nil {
+			return d, fmt.Errorf("engine %s: invalid dsn template: %w", p.Type, err)
+		}
+		d.BuildDSN = func(instance *DatabaseInstance) string {
+			var buf bytes.Buffer
+			if err := dsn.Execute(&buf, profileTemplateData{
+				Database: instance.Database,
+				Username: instance.Username,
+				Password: instance.Password,
+				Port:     instance.Port,
+			}); err != nil {
+				return ""
+			}
+			return buf.String()
+		}
+	}
+
+	if err := d.Validate(); err != nil {
+		return d, err
+	}
+	if err := checkProfileTemplates(d, p.DSN); err != nil {
+		return d, fmt.Errorf("engine %s: %w", d.Type, err)
+	}
+	if d.DriverName != "" && !slices.Contains(sql.Drivers(), d.DriverName) {
+		return d, fmt.Errorf("engine %s: unknown SQL driver %q (available: %v)", d.Type, d.DriverName, sql.Drivers())
+	}
+
+	return d, nil
+}
+
+// checkProfileTemplates parses and renders every template of a descriptor with sample data,
+// so that syntax errors and unknown fields are reported at startup.
+func checkProfileTemplates(d EngineDescriptor, dsn string) error {
+	templates := map[string]string{"dsn": dsn}
+	for key, value := range d.Environment {
+		templates["environment "+key] = value
+	}
+	for key, value := range d.ClientEnvironment {
+		templates["client_environment "+key] = value
+	}
+	for name, list := range map[string][]string{
+		"command":            d.Command,
+		"health_check":       d.HealthCheck,
+		"setup_command":      d.SetupCommand,
+		"dump_command":       d.DumpCommand,
+		"restore_command":    d.RestoreCommand,
+		"shell_command":      d.ShellCommand,
+		"host_shell_command": d.HostShellCommand,
+	} {
+		for i, value := range list {
+			templates[fmt.Sprintf("%s[%d]", name, i)] = value
+		}
+	}
+
+	sample := profileTemplateData{Database: "db", Username: "user", Password: "password", Port: d.Port}
+	for name, value := range templates {
+		tmpl, err := template.New(name).Parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s template: %w", name, err)
+		}
+		if err := tmpl.Execute(io.Discard, sample); err != nil {
+			return fmt.Errorf("invalid %s template: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// findEngine looks up an engine among the given descriptors, then in the registry.
+func findEngine(descriptors []EngineDescriptor, dbType DatabaseType) (EngineDescriptor, bool) {
+	for _, d := range descriptors {
+		if d.Type == dbType {
+			return d, true
+		}
+	}
+	return LookupEngine(dbType)
+}
+
+// mergeTemplates returns base with the entries of overrides added or replaced.
+func mergeTemplates(base, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(overrides))
+	}
+	maps.Copy(base, overrides)
+	return base
+}
+
+// setIfNotEmpty sets dst to value unless value is empty.
+func setIfNotEmpty(dst *string, value string) {
+	if value != "" {
+		*dst = value
+	}
+}
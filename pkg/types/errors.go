@@ -0,0 +1,9 @@
+package types
+
+import "errors"
+
+// ErrInstanceUnhealthy is returned when a database container does not become healthy in time.
+var ErrInstanceUnhealthy = errors.New("instance did not become healthy")
+
+// ErrQuotaExceeded is returned when creating an instance would exceed a server quota.
+var ErrQuotaExceeded = errors.New("quota exceeded")
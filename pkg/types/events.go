@@ -0,0 +1,28 @@
+package types
+
+// InstanceEventType is the kind of change an InstanceEvent reports.
+type InstanceEventType string
+
+const (
+	// InstanceEventCreated reports a new instance, including one attached from a volume.
+	InstanceEventCreated InstanceEventType = "created"
+
+	// InstanceEventDropped reports an instance whose container was removed.
+	InstanceEventDropped InstanceEventType = "dropped"
+
+	// InstanceEventStatusChanged reports an instance that was stopped, started or restarted,
+	// or whose health changed.
+	InstanceEventStatusChanged InstanceEventType = "status_changed"
+)
+
+// InstanceEvent reports a change to a managed instance.
+type InstanceEvent struct {
+	// Type is the kind of change.
+	Type InstanceEventType `json:"type"`
+
+	// InstanceID is the ID of the changed instance.
+	InstanceID string `json:"instance_id"`
+
+	// Status is the instance's status after the change. Empty for dropped instances.
+	Status string `json:"status,omitempty"`
+}
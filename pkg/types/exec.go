@@ -0,0 +1,95 @@
+package types
+
+import (
+	"io"
+	"time"
+)
+
+// Exec limits for commands run inside instance containers via MCP.
+const (
+	// DefaultExecTimeout is the default timeout for commands run via MCP.
+	DefaultExecTimeout = 60 * time.Second
+
+	// MaxExecTimeout is the maximum timeout for commands run via MCP.
+	MaxExecTimeout = 30 * time.Minute
+
+	// DefaultExecMaxOutputBytes is the default amount of stdout and stderr (each) returned via MCP.
+	DefaultExecMaxOutputBytes = 64 * 1024
+
+	// MaxExecMaxOutputBytes is the maximum amount of stdout and stderr (each) returned via MCP.
+	MaxExecMaxOutputBytes = 4 * 1024 * 1024
+)
+
+// ExecOptions configures a command run inside an instance's container.
+type ExecOptions struct {
+	// Cmd is the command and its arguments.
+	Cmd []string
+
+	// Env holds additional environment variables (KEY=value).
+	Env []string
+
+	// User runs the command as this user (defaults to the container's user).
+	User string
+
+	// WorkingDir runs the command in this directory (defaults to the container's working directory).
+	WorkingDir string
+
+	// Stdin is streamed to the command's standard input if not nil.
+	Stdin io.Reader
+
+	// Stdout receives the command's standard output (discarded if nil).
+	Stdout io.Writer
+
+	// Stderr receives the command's standard error (discarded if nil).
+	Stderr io.Writer
+
+	// Timeout stops waiting for the command after this long (zero means no timeout).
+	Timeout time.Duration
+
+	// Tty allocates a pseudo-terminal for interactive commands. Standard error is then
+	// merged into standard output.
+	Tty bool
+
+	// ConsoleSize is the initial terminal size as [height, width] when Tty is set.
+	ConsoleSize *[2]uint
+
+	// Resize receives terminal size changes as [height, width] when Tty is set.
+	Resize <-chan [2]uint
+}
+
+// ExecResult is the captured outcome of a command run inside an instance's container.
+type ExecResult struct {
+	// ExitCode is the command's exit code.
+	ExitCode int `json:"exit_code"`
+
+	// Stdout is the captured standard output.
+	Stdout string `json:"stdout"`
+
+	// Stderr is the captured standard error.
+	Stderr string `json:"stderr"`
+
+	// StdoutTruncated reports whether standard output exceeded the output limit.
+	StdoutTruncated bool `json:"stdout_truncated,omitempty"`
+
+	// StderrTruncated reports whether standard error exceeded the output limit.
+	StderrTruncated bool `json:"stderr_truncated,omitempty"`
+
+	// Duration is how long the command ran.
+	Duration string `json:"duration"`
+}
+
+// ShellCommand describes how to open an interactive client session on an instance.
+type ShellCommand struct {
+	// Instance is the instance the session connects to.
+	Instance *DatabaseInstance
+
+	// Host is the client command to run on the host, connecting through the instance's port.
+	// Empty if the engine has no host client.
+	Host []string
+
+	// HostEnv holds the environment variables (KEY=value) that authenticate the host client.
+	HostEnv []string
+
+	// Container is the client command to run inside the container with a TTY.
+	Container []string
+}
@@ -17,6 +17,18 @@ const (
 	DatabaseTypeMySQL DatabaseType = "mysql"
 	// DatabaseTypeMariaDB represents MariaDB database.
 	DatabaseTypeMariaDB DatabaseType = "mariadb"
+	// DatabaseTypeRedis represents Redis.
+	DatabaseTypeRedis DatabaseType = "redis"
+	// DatabaseTypeValkey represents Valkey, the Redis-compatible fork.
+	DatabaseTypeValkey DatabaseType = "valkey"
+	// DatabaseTypeMongoDB represents MongoDB.
+	DatabaseTypeMongoDB DatabaseType = "mongodb"
+	// DatabaseTypeClickHouse represents ClickHouse.
+	DatabaseTypeClickHouse DatabaseType = "clickhouse"
+	// DatabaseTypeSQLServer represents Microsoft SQL Server.
+	DatabaseTypeSQLServer DatabaseType = "sqlserver"
+	// DatabaseTypeCockroachDB represents CockroachDB.
+	DatabaseTypeCockroachDB DatabaseType = "cockroachdb"
 )
 
 // String returns the string representation of the database type.
@@ -24,65 +36,60 @@ func (dt DatabaseType) String() string {
 	return string(dt)
 }
 
-// IsValid checks if the database type is valid.
+// IsValid checks if the database type is a registered engine.
 func (dt DatabaseType) IsValid() bool {
-	switch dt {
-	case DatabaseTypePostgreSQL, DatabaseTypeMySQL, DatabaseTypeMariaDB:
-		return true
-	default:
-		return false
-	}
+	_, ok := LookupEngine(dt)
+	return ok
 }
 
-// DefaultPort returns the default port for the database type.
+// DefaultPort returns the port the database type listens on inside its container.
 func (dt DatabaseType) DefaultPort() int {
-	switch dt {
-	case DatabaseTypePostgreSQL:
-		return 5432
-	case DatabaseTypeMySQL:
-		return 3306
-	case DatabaseTypeMariaDB:
-		return 3306
-	default:
-		return 0
-	}
+	d, _ := LookupEngine(dt)
+	return d.Port
 }
 
 // DefaultVersion returns the default version for the database type.
 func (dt DatabaseType) DefaultVersion() string {
-	switch dt {
-	case DatabaseTypePostgreSQL:
-		return "17"
-	case DatabaseTypeMySQL:
-		return "8.0"
-	case DatabaseTypeMariaDB:
-		return "11"
-	default:
-		return ""
-	}
+	d, _ := LookupEngine(dt)
+	return d.DefaultVersion
 }
 
 // DefaultDatabase returns the default database name for the database type.
 func (dt DatabaseType) DefaultDatabase() string {
-	switch dt {
-	case DatabaseTypePostgreSQL:
-		return "postgres"
-	case DatabaseTypeMySQL, DatabaseTypeMariaDB:
-		return "mysql"
-	default:
-		return ""
-	}
+	d, _ := LookupEngine(dt)
+	return d.DefaultDatabase
 }
 
 // DefaultUsername returns the default username for the database type.
 func (dt DatabaseType) DefaultUsername() string {
-	switch dt {
-	case DatabaseTypePostgreSQL:
-		return "postgres"
-	case DatabaseTypeMySQL, DatabaseTypeMariaDB:
-		return "root"
+	d, _ := LookupEngine(dt)
+	return d.DefaultUsername
+}
+
+// CleanupPolicy determines which instances are removed when a server shuts down.
+type CleanupPolicy string
+
+const (
+	// CleanupPolicyAll removes every managed instance, regardless of owner.
+	CleanupPolicyAll CleanupPolicy = "all"
+	// CleanupPolicyOwned removes only the instances created by the shutting-down server.
+	CleanupPolicyOwned CleanupPolicy = "owned"
+	// CleanupPolicyNone leaves all instances running.
+	CleanupPolicyNone CleanupPolicy = "none"
+)
+
+// String returns the string representation of the cleanup policy.
+func (cp CleanupPolicy) String() string {
+	return string(cp)
+}
+
+// IsValid checks if the cleanup policy is valid.
+func (cp CleanupPolicy) IsValid() bool {
+	switch cp {
+	case CleanupPolicyAll, CleanupPolicyOwned, CleanupPolicyNone:
+		return true
 	default:
-		return ""
+		return false
 	}
 }
 
@@ -91,7 +98,7 @@ type DatabaseInstance struct {
 	// ID is the unique identifier for this instance.
 	ID string `json:"id"`
 
-	// Type is the database type (postgresql, mysql, mariadb).
+	// Type is the database type (e.g. postgresql, mysql, redis).
 	Type DatabaseType `json:"type"`
 
 	// ContainerID is the Docker container ID.
@@ -121,6 +128,37 @@ type DatabaseInstance struct {
 	// Status represents the current status of the instance.
 	// Possible values: "starting", "running", "stopped", "unhealthy", "unknown"
 	Status string `json:"status"`
+
+	// Owner identifies the server or session that created the instance.
+	// Empty for instances created outside an MCP server (e.g. by the CLI).
+	Owner string `json:"owner,omitempty"`
+
+	// Session is the ID of the MCP session that created the instance, if any.
+	Session string `json:"session,omitempty"`
+
+	// Principal is the name of the authenticated client that created the instance, if any.
+	// Other principals cannot see or manage the instance unless they are admins.
+	Principal string `json:"principal,omitempty"`
+
+	// ExpiresAt is when the instance's time-to-live runs out. Nil if the instance does not expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Volume is the name of the managed volume holding the instance's data.
+	// Empty for non-persistent instances, whose data lives in the container.
+	Volume string `json:"volume,omitempty"`
+
+	// Resources are the instance's effective container resource limits.
+	// Nil for instances created before resource limits were recorded.
+	Resources *ResourceLimits `json:"resources,omitempty"`
+
+	// InitScripts reports the outcome of the init scripts applied when the instance was created.
+	// Only known to the process that created the instance.
+	InitScripts []InitScriptResult `json:"init_scripts,omitempty"`
+}
+
+// IsExpired reports whether the instance's time-to-live has run out at the given time.
+func (i *DatabaseInstance) IsExpired(now time.Time) bool {
+	return i.ExpiresAt != nil && !now.Before(*i.ExpiresAt)
 }
 
 // PostgreSQLInstance represents a PostgreSQL database instance.
@@ -177,7 +215,7 @@ func (p *PostgreSQLInstance) ToGeneric() *DatabaseInstance {
 
 // CreateInstanceOptions holds options for creating a new database instance.
 type CreateInstanceOptions struct {
-	// Type specifies the database type (postgresql, mysql, mariadb).
+	// Type specifies the database type (e.g. postgresql, mysql, redis).
 	Type DatabaseType `json:"type,omitempty"`
 
 	// Version specifies the database version to use (defaults vary by type).
@@ -191,6 +229,115 @@ type CreateInstanceOptions struct {
 
 	// Password specifies the database password (auto-generated if empty).
 	Password string `json:"password,omitempty"`
+
+	// TTL is how long the instance may live before it is dropped by the reaper (zero means no limit).
+	TTL time.Duration `json:"ttl,omitempty"`
+
+	// Persistent stores the instance's data in a managed named volume that can outlive the container.
+	Persistent bool `json:"persistent,omitempty"`
+
+	// InitScripts are applied in order once the instance is healthy. Each entry is inline SQL,
+	// a host file path, a host directory of *.sql files, or an http(s) URL.
+	InitScripts []string `json:"init_scripts,omitempty"`
+
+	// NoWait returns as soon as the container has started instead of waiting for it to become
+	// healthy. The instance is then reported with status "starting".
+	NoWait bool `json:"no_wait,omitempty"`
+
+	// Memory is the container memory limit in bytes (server or engine default if zero).
+	Memory int64 `json:"memory,omitempty"`
+
+	// CPUs is the number of CPUs the container may use, e.g. 0.5 (server default if zero).
+	CPUs float64 `json:"cpus,omitempty"`
+
+	// ShmSize is the size of /dev/shm in bytes (Docker's default of 64 MB if zero).
+	ShmSize int64 `json:"shm_size,omitempty"`
+
+	// Tmpfs keeps the data directory on a tmpfs mount. This speeds up write-heavy tests, but the
+	// data counts towards the memory limit and is lost when the container stops.
+	Tmpfs bool `json:"tmpfs,omitempty"`
+}
+
+// Init script statuses reported in InitScriptResult.
+const (
+	InitScriptApplied = "applied"
+	InitScriptFailed  = "failed"
+	InitScriptSkipped = "skipped"
+)
+
+// InitScriptResult reports the outcome of a single init script.
+type InitScriptResult struct {
+	// Source is where the script came from: a file path, a URL, or "inline[N]".
+	Source string `json:"source"`
+
+	// Status is InitScriptApplied, InitScriptFailed, or InitScriptSkipped after an earlier failure.
+	Status string `json:"status"`
+
+	// Error is the failure message, if the script failed.
+	Error string `json:"error,omitempty"`
+
+	// Duration is how long the script took to apply.
+	Duration string `json:"duration,omitempty"`
+}
+
+// InitScriptPolicy restricts the init scripts that remote clients may use. Remote clients may
+// always pass inline SQL; host paths and URLs are only allowed if they fall under one of the
+// listed directories or hosts. Local access is not restricted.
+type InitScriptPolicy struct {
+	// Restricted applies the policy. It is set for servers whose clients connect over the
+	// network, whether they authenticate or not.
+	Restricted bool
+
+	// AllowedDirs are host directories whose files and subdirectories remote clients may use.
+	AllowedDirs []string
+
+	// AllowedHosts are host names (without port) that remote clients may fetch scripts from.
+	AllowedHosts []string
+}
+
+// CloneInstanceOptions holds options for cloning a database instance. The clone always has the
+// source's type, version, database, username and resource limits.
+type CloneInstanceOptions struct {
+	// TTL is how long the clone may live before it is dropped by the reaper (zero means no limit).
+	TTL time.Duration `json:"ttl,omitempty"`
+
+	// Persistent stores the clone's data in a managed named volume that can outlive the container.
+	Persistent bool `json:"persistent,omitempty"`
+}
+
+// VolumeInfo describes a managed volume holding the data of a persistent instance.
+type VolumeInfo struct {
+	// Name is the Docker volume name.
+	Name string `json:"name"`
+
+	// InstanceID is the ID of the instance the volume belongs to.
+	InstanceID string `json:"instance_id"`
+
+	// Type is the database type stored in the volume.
+	Type DatabaseType `json:"type"`
+
+	// Version is the database version that initialized the volume.
+	Version string `json:"version"`
+
+	// Database is the name of the database.
+	Database string `json:"database"`
+
+	// Username is the database username.
+	Username string `json:"username"`
+
+	// Principal is the name of the authenticated client that created the volume, if any.
+	Principal string `json:"principal,omitempty"`
+
+	// CreatedAt is the timestamp when the volume was created.
+	CreatedAt time.Time `json:"created_at"`
+
+	// ContainerID is the ID of the container using the volume. Empty if the volume is detached.
+	ContainerID string `json:"container_id,omitempty"`
+}
+
+// IsAttached reports whether the volume is in use by an instance container.
+func (v *VolumeInfo) IsAttached() bool {
+	return v.ContainerID != ""
 }
 
 // Container is an alias for Docker container type to avoid importing Docker types everywhere.
@@ -3,6 +3,7 @@ package types
 
 import (
 	"context"
+	"io"
 )
 
 // DatabaseManager defines the interface for managing database instances.
@@ -19,9 +20,55 @@ type DatabaseManager interface {
 	// DropInstance removes a database instance.
 	DropInstance(ctx context.Context, id string) error
 
+	// StopInstance stops a database instance without removing its container or data.
+	StopInstance(ctx context.Context, id string) error
+
+	// StartInstance starts a stopped database instance and waits for it to become healthy.
+	StartInstance(ctx context.Context, id string) error
+
+	// RestartInstance stops and starts a database instance.
+	RestartInstance(ctx context.Context, id string) error
+
+	// DumpDatabase writes a logical SQL dump of an instance's database to w.
+	DumpDatabase(ctx context.Context, id string, w io.Writer) error
+
+	// RestoreDatabase applies SQL read from r to an instance's database.
+	RestoreDatabase(ctx context.Context, id string, r io.Reader) error
+
+	// ExecInInstance runs a command inside an instance's container and returns its exit code.
+	ExecInInstance(ctx context.Context, id string, opts ExecOptions) (int, error)
+
+	// ShellCommand returns the commands that open an interactive client session on an instance.
+	ShellCommand(ctx context.Context, id string) (*ShellCommand, error)
+
+	// StreamLogs copies an instance's container logs to the writers in opts.
+	StreamLogs(ctx context.Context, id string, opts LogOptions) error
+
 	// HealthCheck performs a health check on a database instance.
 	HealthCheck(ctx context.Context, id string) (*HealthCheckResult, error)
 
+	// ExecuteSQL executes a SQL statement against a database instance.
+	ExecuteSQL(ctx context.Context, id string, query string, opts QueryOptions) (*QueryResult, error)
+
+	// DescribeSchema returns the tables in an instance's database with their columns.
+	DescribeSchema(ctx context.Context, id string) ([]TableSchema, error)
+
+	// ActiveConnections returns the number of client connections to a database instance,
+	// excluding the connection used to count them.
+	ActiveConnections(ctx context.Context, id string) (int, error)
+
+	// DetachInstance removes a persistent instance's container but keeps its data volume.
+	DetachInstance(ctx context.Context, id string) (*VolumeInfo, error)
+
+	// AttachVolume recreates an instance from a detached data volume.
+	AttachVolume(ctx context.Context, name string) (*DatabaseInstance, error)
+
+	// ListVolumes returns all data volumes of this type.
+	ListVolumes(ctx context.Context) ([]*VolumeInfo, error)
+
+	// DeleteVolume removes a detached data volume and all its data.
+	DeleteVolume(ctx context.Context, name string) error
+
 	// Cleanup removes all instances managed by this manager.
 	Cleanup(ctx context.Context) error
 
@@ -45,6 +92,21 @@ type HealthCheckResult struct {
 
 	// Timestamp is when the health check was performed.
 	Timestamp string `json:"timestamp"`
+
+	// ContainerStatus is the health of the instance's container as reported by Docker.
+	ContainerStatus string `json:"container_status,omitempty"`
+
+	// Latency is how long it took to connect with the instance's credentials and run a test query.
+	Latency string `json:"latency,omitempty"`
+
+	// ServerVersion is the version reported by the database server.
+	ServerVersion string `json:"server_version,omitempty"`
+
+	// Uptime is how long the database server has been running.
+	Uptime string `json:"uptime,omitempty"`
+
+	// ActiveConnections is the number of other client connections to the instance.
+	ActiveConnections *int `json:"active_connections,omitempty"`
 }
 
 // HealthStatus represents the health status of a database instance.
@@ -60,6 +122,9 @@ const (
 	// HealthStatusStarting indicates the instance is starting up.
 	HealthStatusStarting HealthStatus = "starting"
 
+	// HealthStatusStopped indicates the instance's container is not running.
+	HealthStatusStopped HealthStatus = "stopped"
+
 	// HealthStatusUnknown indicates the health status is unknown.
 	HealthStatusUnknown HealthStatus = "unknown"
 )
@@ -68,3 +133,15 @@ const (
 func (hs HealthStatus) String() string {
 	return string(hs)
 }
+
+// CredentialStore persists instance passwords so they can be recovered by later processes.
+type CredentialStore interface {
+	// SavePassword stores the password for an instance.
+	SavePassword(instanceID, password string) error
+
+	// LoadPassword returns the stored password for an instance, or an empty string if none is stored.
+	LoadPassword(instanceID string) (string, error)
+
+	// DeletePassword removes the stored password for an instance.
+	DeletePassword(instanceID string) error
+}
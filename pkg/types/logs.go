@@ -0,0 +1,70 @@
+package types
+
+import "io"
+
+// Log limits for instance logs returned via MCP.
+const (
+	// DefaultLogTail is the default number of log lines returned via MCP.
+	DefaultLogTail = 100
+
+	// MaxLogTail is the maximum number of log lines returned via MCP.
+	MaxLogTail = 5000
+
+	// MaxLogScanLines is the number of most recent log lines searched when a level or
+	// pattern filter is given.
+	MaxLogScanLines = 10000
+)
+
+// Log levels accepted by LogFilter. Each level includes the more severe ones.
+const (
+	LogLevelError   = "error"
+	LogLevelWarning = "warning"
+	LogLevelInfo    = "info"
+)
+
+// LogOptions configures streaming of an instance's container logs.
+type LogOptions struct {
+	// Follow keeps streaming new log output until the container stops or the context is cancelled.
+	Follow bool
+
+	// Since only shows logs since a timestamp (RFC 3339) or relative duration (e.g. 10m).
+	Since string
+
+	// Tail is the number of lines to show from the end of the logs ("all" or empty for all).
+	Tail string
+
+	// Timestamps prefixes each line with its timestamp.
+	Timestamps bool
+
+	// Stdout receives the container's standard output (discarded if nil).
+	Stdout io.Writer
+
+	// Stderr receives the container's standard error (discarded if nil).
+	Stderr io.Writer
+}
+
+// LogFilter selects the log lines returned by a bounded log query.
+type LogFilter struct {
+	// Tail is the maximum number of lines returned, counted from the end.
+	Tail int
+
+	// Since only considers logs since a timestamp (RFC 3339) or relative duration (e.g. 10m).
+	Since string
+
+	// Level only returns lines of this severity or higher (error, warning, info).
+	Level string
+
+	// Pattern only returns lines matching this regular expression.
+	Pattern string
+
+	// Timestamps prefixes each line with its timestamp.
+	Timestamps bool
+}
+
+// LogsResult holds the log lines returned by a bounded log query.
+type LogsResult struct {
+	InstanceID string   `json:"instance_id"`
+	Lines      []string `json:"lines"`
+	Scanned    int      `json:"scanned"`
+	Truncated  bool     `json:"truncated"`
+}
@@ -0,0 +1,33 @@
+package types
+
+import "context"
+
+// Principal is the authenticated client on whose behalf a request is made.
+type Principal struct {
+	// Name identifies the client. It is recorded on the instances and volumes the client creates.
+	Name string
+
+	// Admin grants access to the instances and volumes of all principals.
+	Admin bool
+}
+
+// CanAccess reports whether the principal may access a resource created by owner, the name of
+// the principal that created it. A nil principal stands for local, unauthenticated access (the
+// stdio transport and the CLI), which is not restricted.
+func (p *Principal) CanAccess(owner string) bool {
+	return p == nil || p.Admin || p.Name == owner
+}
+
+// principalKey is the context key of the authenticated principal.
+type principalKey struct{}
+
+// ContextWithPrincipal returns a context carrying the principal a request is made for.
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal carried by the context, or nil for local access.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
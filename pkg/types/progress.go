@@ -0,0 +1,51 @@
+package types
+
+import "context"
+
+// ProgressPhase is a phase of creating an instance.
+type ProgressPhase string
+
+const (
+	// ProgressPhasePull is pulling the instance's image.
+	ProgressPhasePull ProgressPhase = "pull"
+	// ProgressPhaseCreate is creating the instance's container.
+	ProgressPhaseCreate ProgressPhase = "create"
+	// ProgressPhaseStart is starting the instance's container.
+	ProgressPhaseStart ProgressPhase = "start"
+	// ProgressPhaseHealth is waiting for the instance to become healthy.
+	ProgressPhaseHealth ProgressPhase = "health"
+	// ProgressPhaseSetup is running the engine's setup command and init scripts.
+	ProgressPhaseSetup ProgressPhase = "setup"
+)
+
+// Progress is an update on a long-running operation.
+type Progress struct {
+	// Phase is the phase the operation is in.
+	Phase ProgressPhase
+
+	// Fraction is how much of the phase is complete, from 0 to 1.
+	Fraction float64
+
+	// Message describes the progress for humans, e.g. "Pulling postgres:17: 2/7 layers".
+	Message string
+}
+
+// ProgressFunc receives the progress updates of an operation.
+type ProgressFunc func(Progress)
+
+// progressKey is the context key of the progress function.
+type progressKey struct{}
+
+// ContextWithProgress returns a context carrying a function that receives the progress of the
+// operations run with the context.
+func ContextWithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress passes a progress update to the progress function carried by the context, if
+// any.
+func ReportProgress(ctx context.Context, progress Progress) {
+	if fn, _ := ctx.Value(progressKey{}).(ProgressFunc); fn != nil {
+		fn(progress)
+	}
+}
@@ -0,0 +1,101 @@
+// Package types defines the data types used for executing SQL against database instances.
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultQueryMaxRows is the default maximum number of rows returned by a query.
+	DefaultQueryMaxRows = 1000
+	// DefaultQueryMaxBytes is the default maximum size of the returned rows, in bytes.
+	DefaultQueryMaxBytes = 1024 * 1024
+	// DefaultQueryTimeout is the default statement timeout.
+	DefaultQueryTimeout = 30 * time.Second
+
+	// MaxQueryRows is the upper bound accepted for QueryOptions.MaxRows.
+	MaxQueryRows = 10000
+	// MaxQueryBytes is the upper bound accepted for QueryOptions.MaxBytes.
+	MaxQueryBytes = 8 * 1024 * 1024
+	// MaxQueryTimeout is the upper bound accepted for QueryOptions.Timeout.
+	MaxQueryTimeout = 5 * time.Minute
+)
+
+// QueryOptions holds options for executing SQL against a database instance.
+type QueryOptions struct {
+	// Params are positional parameters bound to the placeholders in the statement.
+	Params []any `json:"params,omitempty"`
+
+	// MaxRows limits the number of rows returned (defaults to DefaultQueryMaxRows).
+	MaxRows int `json:"max_rows,omitempty"`
+
+	// MaxBytes limits the approximate JSON size of the returned rows (defaults to DefaultQueryMaxBytes).
+	MaxBytes int `json:"max_bytes,omitempty"`
+
+	// Timeout is the statement timeout (defaults to DefaultQueryTimeout).
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// QueryColumn describes a column in a query result.
+type QueryColumn struct {
+	// Name is the column name.
+	Name string `json:"name"`
+
+	// Type is the database-specific type name of the column (e.g., "INT4", "VARCHAR").
+	Type string `json:"type"`
+}
+
+// QueryResult represents the result of executing SQL against a database instance.
+type QueryResult struct {
+	// Columns describes the columns of the returned rows. Empty for statements without a result set.
+	Columns []QueryColumn `json:"columns"`
+
+	// Rows holds the returned rows, one value per column.
+	Rows [][]any `json:"rows"`
+
+	// RowCount is the number of rows returned.
+	RowCount int `json:"row_count"`
+
+	// RowsAffected is the number of rows affected by a statement without a result set.
+	RowsAffected int64 `json:"rows_affected,omitempty"`
+
+	// Truncated indicates that more rows were available than were returned.
+	Truncated bool `json:"truncated"`
+
+	// TruncatedReason explains which limit caused the truncation ("max_rows" or "max_bytes").
+	TruncatedReason string `json:"truncated_reason,omitempty"`
+
+	// Duration is how long the statement took to execute.
+	Duration string `json:"duration"`
+}
+
+// ValidateQueryOptions validates and sets defaults for QueryOptions.
+func ValidateQueryOptions(opts *QueryOptions) error {
+	if opts.MaxRows < 0 {
+		return fmt.Errorf("max_rows must not be negative")
+	}
+	if opts.MaxBytes < 0 {
+		return fmt.Errorf("max_bytes must not be negative")
+	}
+	if opts.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+
+	if opts.MaxRows == 0 {
+		opts.MaxRows = DefaultQueryMaxRows
+	}
+	opts.MaxRows = min(opts.MaxRows, MaxQueryRows)
+
+	if opts.MaxBytes == 0 {
+		opts.MaxBytes = DefaultQueryMaxBytes
+	}
+	opts.MaxBytes = min(opts.MaxBytes, MaxQueryBytes)
+
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultQueryTimeout
+	}
+	opts.Timeout = min(opts.Timeout, MaxQueryTimeout)
+
+	return nil
+}
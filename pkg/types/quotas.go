@@ -0,0 +1,148 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-units"
+)
+
+// Quota names reported in QuotaError.
+const (
+	QuotaMaxInstances           = "max_instances"
+	QuotaMaxInstancesPerType    = "max_instances_per_type"
+	QuotaMaxTotalMemory         = "max_total_memory"
+	QuotaMaxInstancesPerSession = "max_instances_per_session"
+)
+
+// QuotaPolicy limits the instances a server creates. Zero values disable a quota.
+type QuotaPolicy struct {
+	// MaxInstances is the maximum number of managed instances, including stopped ones.
+	MaxInstances int
+
+	// MaxInstancesPerType is the maximum number of managed instances of each listed type.
+	MaxInstancesPerType map[DatabaseType]int
+
+	// MaxTotalMemory is the maximum sum in bytes of the memory limits of instances that are
+	// not stopped.
+	MaxTotalMemory int64
+
+	// MaxInstancesPerSession is the maximum number of instances an MCP session may own.
+	MaxInstancesPerSession int
+}
+
+// IsZero reports whether the policy has no quotas.
+func (q QuotaPolicy) IsZero() bool {
+	return q.MaxInstances == 0 && len(q.MaxInstancesPerType) == 0 && q.MaxTotalMemory == 0 && q.MaxInstancesPerSession == 0
+}
+
+// Validate checks that the quotas are not negative and name registered engines.
+func (q QuotaPolicy) Validate() error {
+	if q.MaxInstances < 0 || q.MaxTotalMemory < 0 || q.MaxInstancesPerSession < 0 {
+		return fmt.Errorf("quotas must not be negative")
+	}
+	for dbType, limit := range q.MaxInstancesPerType {
+		if !dbType.IsValid() {
+			return fmt.Errorf("invalid database type in instance quota: %s", dbType)
+		}
+		if limit < 0 {
+			return fmt.Errorf("%s instance quota must not be negative", dbType)
+		}
+	}
+	return nil
+}
+
+// QuotaUsage is the usage that quotas are checked against.
+type QuotaUsage struct {
+	// Instances is the number of managed instances.
+	Instances int
+
+	// InstancesByType is the number of managed instances of each type.
+	InstancesByType map[DatabaseType]int
+
+	// Memory is the sum in bytes of the memory limits of instances that are not stopped.
+	Memory int64
+
+	// SessionInstances is the number of instances owned by the requesting session.
+	SessionInstances int
+}
+
+// Check returns a *QuotaError if adding an instance of the given type and memory limit to
+// the usage would exceed a quota. The session quota is only checked if session is set.
+func (q QuotaPolicy) Check(usage QuotaUsage, dbType DatabaseType, memory int64, session bool) error {
+	if q.MaxInstances > 0 && usage.Instances >= q.MaxInstances {
+		return &QuotaError{Quota: QuotaMaxInstances, Limit: int64(q.MaxInstances), Current: int64(usage.Instances), Requested: 1}
+	}
+	if limit, ok := q.MaxInstancesPerType[dbType]; ok && usage.InstancesByType[dbType] >= limit {
+		return &QuotaError{Quota: QuotaMaxInstancesPerType, Type: dbType, Limit: int64(limit), Current: int64(usage.InstancesByType[dbType]), Requested: 1}
+	}
+	if err := q.CheckMemory(usage, memory); err != nil {
+		return err
+	}
+	if session && q.MaxInstancesPerSession > 0 && usage.SessionInstances >= q.MaxInstancesPerSession {
+		return &QuotaError{Quota: QuotaMaxInstancesPerSession, Limit: int64(q.MaxInstancesPerSession), Current: int64(usage.SessionInstances), Requested: 1}
+	}
+	return nil
+}
+
+// CheckMemory returns a *QuotaError if starting a stopped instance with the given memory limit
+// would exceed the memory quota. Stopped instances already count towards the other quotas.
+func (q QuotaPolicy) CheckMemory(usage QuotaUsage, memory int64) error {
+	if q.MaxTotalMemory > 0 && usage.Memory+memory > q.MaxTotalMemory {
+		return &QuotaError{Quota: QuotaMaxTotalMemory, Limit: q.MaxTotalMemory, Current: usage.Memory, Requested: memory}
+	}
+	return nil
+}
+
+// QuotaError reports a quota that a new instance would exceed. It wraps ErrQuotaExceeded.
+type QuotaError struct {
+	// Quota is the name of the exceeded quota, e.g. QuotaMaxInstances.
+	Quota string `json:"quota"`
+
+	// Type is the database type of a QuotaMaxInstancesPerType quota.
+	Type DatabaseType `json:"type,omitempty"`
+
+	// Limit is the quota's value: a number of instances, or bytes of memory.
+	Limit int64 `json:"limit"`
+
+	// Current is the usage before the new instance.
+	Current int64 `json:"current"`
+
+	// Requested is what the new instance needs.
+	Requested int64 `json:"requested"`
+}
+
+// Error returns a description of the exceeded quota.
+func (e *QuotaError) Error() string {
+	switch e.Quota {
+	case QuotaMaxInstancesPerType:
+		return fmt.Sprintf("%s: %d of %d %s instances exist", ErrQuotaExceeded, e.Current, e.Limit, e.Type)
+	case QuotaMaxTotalMemory:
+		return fmt.Sprintf("%s: instances use %s of %s of memory and the new instance needs %s", ErrQuotaExceeded,
+			units.BytesSize(float64(e.Current)), units.BytesSize(float64(e.Limit)), units.BytesSize(float64(e.Requested)))
+	case QuotaMaxInstancesPerSession:
+		return fmt.Sprintf("%s: this session has %d of %d instances", ErrQuotaExceeded, e.Current, e.Limit)
+	default:
+		return fmt.Sprintf("%s: %d of %d instances exist", ErrQuotaExceeded, e.Current, e.Limit)
+	}
+}
+
+// Unwrap returns ErrQuotaExceeded.
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// sessionKey is the context key of the MCP session ID.
+type sessionKey struct{}
+
+// ContextWithSession returns a context carrying the ID of the MCP session a request belongs to.
+// Instances created with the context are recorded as belonging to the session.
+func ContextWithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, sessionID)
+}
+
+// SessionFromContext returns the MCP session ID carried by the context, if any.
+func SessionFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(sessionKey{}).(string)
+	return sessionID
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// DefaultCPUs is the number of CPUs an instance may use when neither the request nor the
+// server configuration sets one.
+const DefaultCPUs = 1.0
+
+// MinMemoryLimit is the smallest container memory limit Docker accepts.
+const MinMemoryLimit = 6 * 1024 * 1024
+
+// ResourceLimits describes the effective container resource limits of an instance.
+type ResourceLimits struct {
+	// Memory is the container memory limit in bytes.
+	Memory int64 `json:"memory"`
+
+	// CPUs is the number of CPUs the container may use.
+	CPUs float64 `json:"cpus"`
+
+	// ShmSize is the size of /dev/shm in bytes. Zero means Docker's default (64 MB).
+	ShmSize int64 `json:"shm_size,omitempty"`
+
+	// Tmpfs reports whether the data directory is a tmpfs mount held in memory.
+	Tmpfs bool `json:"tmpfs,omitempty"`
+}
+
+// String returns a short human-readable summary of the limits, e.g. "512MiB memory, 1 CPU".
+func (r ResourceLimits) String() string {
+	cpus := strconv.FormatFloat(r.CPUs, 'f', -1, 64) + " CPU"
+	if r.CPUs != 1 {
+		cpus += "s"
+	}
+	parts := []string{units.BytesSize(float64(r.Memory)) + " memory", cpus}
+	if r.ShmSize > 0 {
+		parts = append(parts, units.BytesSize(float64(r.ShmSize))+" shm")
+	}
+	if r.Tmpfs {
+		parts = append(parts, "tmpfs data")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ResourcePolicy holds the server-wide resource defaults and maximums applied to new instances.
+// Zero values fall back to the built-in defaults or leave the resource unbounded.
+type ResourcePolicy struct {
+	// DefaultMemory is the memory limit in bytes of instances that do not request one
+	// (default: DefaultMemoryLimit). Engines that need more, such as SQL Server, get their own.
+	DefaultMemory int64
+
+	// MaxMemory is the largest memory limit in bytes an instance may request (zero: no maximum).
+	MaxMemory int64
+
+	// DefaultCPUs is the CPU limit of instances that do not request one (default: DefaultCPUs).
+	DefaultCPUs float64
+
+	// MaxCPUs is the largest CPU limit an instance may request (zero: no maximum).
+	MaxCPUs float64
+
+	// MaxShmSize is the largest /dev/shm size in bytes an instance may request (zero: no maximum).
+	MaxShmSize int64
+}
+
+// Validate checks that the policy's values are consistent.
+func (p ResourcePolicy) Validate() error {
+	if p.DefaultMemory < 0 || p.MaxMemory < 0 || p.DefaultCPUs < 0 || p.MaxCPUs < 0 || p.MaxShmSize < 0 {
+		return fmt.Errorf("resource defaults and maximums must not be negative")
+	}
+	if p.DefaultMemory != 0 && p.DefaultMemory < MinMemoryLimit {
+		return fmt.Errorf("default memory must be at least %s", units.BytesSize(MinMemoryLimit))
+	}
+	if p.MaxMemory != 0 && p.MaxMemory < MinMemoryLimit {
+		return fmt.Errorf("maximum memory must be at least %s", units.BytesSize(MinMemoryLimit))
+	}
+	if p.MaxMemory != 0 && p.DefaultMemory > p.MaxMemory {
+		return fmt.Errorf("default memory %s exceeds the maximum of %s", units.BytesSize(float64(p.DefaultMemory)), units.BytesSize(float64(p.MaxMemory)))
+	}
+	if p.MaxCPUs != 0 && p.DefaultCPUs > p.MaxCPUs {
+		return fmt.Errorf("default CPUs %g exceed the maximum of %g", p.DefaultCPUs, p.MaxCPUs)
+	}
+	return nil
+}
+
+// Resolve returns the effective limits for the requested options. Requests above a maximum are
+// rejected; defaults above a maximum are lowered to it. engineMemory is the engine's own memory
+// requirement (zero if it has none), used when it exceeds the default.
+func (p ResourcePolicy) Resolve(opts CreateInstanceOptions, engineMemory int64) (ResourceLimits, error) {
+	limits := ResourceLimits{
+		Memory:  opts.Memory,
+		CPUs:    opts.CPUs,
+		ShmSize: opts.ShmSize,
+		Tmpfs:   opts.Tmpfs,
+	}
+
+	if limits.Memory == 0 {
+		limits.Memory = p.DefaultMemory
+		if limits.Memory == 0 {
+			limits.Memory = DefaultMemoryLimit
+		}
+		limits.Memory = max(limits.Memory, engineMemory)
+		if p.MaxMemory > 0 {
+			limits.Memory = min(limits.Memory, p.MaxMemory)
+		}
+	} else if p.MaxMemory > 0 && limits.Memory > p.MaxMemory {
+		return limits, fmt.Errorf("memory limit %s exceeds the server maximum of %s",
+			units.BytesSize(float64(limits.Memory)), units.BytesSize(float64(p.MaxMemory)))
+	}
+
+	if limits.CPUs == 0 {
+		limits.CPUs = p.DefaultCPUs
+		if limits.CPUs == 0 {
+			limits.CPUs = DefaultCPUs
+		}
+		if p.MaxCPUs > 0 {
+			limits.CPUs = min(limits.CPUs, p.MaxCPUs)
+		}
+	} else if p.MaxCPUs > 0 && limits.CPUs > p.MaxCPUs {
+		return limits, fmt.Errorf("CPU limit %g exceeds the server maximum of %g", limits.CPUs, p.MaxCPUs)
+	}
+
+	if p.MaxShmSize > 0 && limits.ShmSize > p.MaxShmSize {
+		return limits, fmt.Errorf("shm size %s exceeds the server maximum of %s",
+			units.BytesSize(float64(limits.ShmSize)), units.BytesSize(float64(p.MaxShmSize)))
+	}
+
+	return limits, nil
+}
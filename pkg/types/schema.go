@@ -0,0 +1,22 @@
+package types
+
+// TableSchema describes a table in an instance's database.
+type TableSchema struct {
+	// Name is the table name.
+	Name string `json:"name"`
+
+	// Columns are the table's columns in their defined order.
+	Columns []ColumnSchema `json:"columns"`
+}
+
+// ColumnSchema describes a table column.
+type ColumnSchema struct {
+	// Name is the column name.
+	Name string `json:"name"`
+
+	// Type is the column's data type as reported by the database.
+	Type string `json:"type"`
+
+	// Nullable reports whether the column accepts NULL.
+	Nullable bool `json:"nullable"`
+}
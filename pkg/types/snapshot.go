@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// SnapshotMethodLogical identifies snapshots taken with the engine's dump tool
+// (pg_dump, mysqldump, mariadb-dump) and restored with its client.
+const SnapshotMethodLogical = "logical"
+
+// Snapshot describes a point-in-time copy of an instance's database stored in the local snapshot store.
+type Snapshot struct {
+	// ID is the unique identifier for this snapshot.
+	ID string `json:"id"`
+
+	// Name is an optional human-readable label.
+	Name string `json:"name,omitempty"`
+
+	// InstanceID is the ID of the instance the snapshot was taken from.
+	InstanceID string `json:"instance_id"`
+
+	// Type is the database type of the source instance.
+	Type DatabaseType `json:"type"`
+
+	// Version is the database version of the source instance.
+	Version string `json:"version"`
+
+	// Database is the name of the database that was captured.
+	Database string `json:"database"`
+
+	// Username is the database username of the source instance.
+	Username string `json:"username"`
+
+	// Method is how the snapshot was taken (currently always SnapshotMethodLogical).
+	Method string `json:"method"`
+
+	// Size is the size of the stored snapshot data in bytes.
+	Size int64 `json:"size"`
+
+	// Principal is the name of the authenticated client that took the snapshot, if any.
+	Principal string `json:"principal,omitempty"`
+
+	// CreatedAt is the timestamp when the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/google/uuid"
 )
@@ -39,6 +40,55 @@ func GeneratePassword(length int) (string, error) {
 	return password, nil
 }
 
+// GenerateStrongPassword generates a random password of the specified length that satisfies
+// IsStrongPassword.
+func GenerateStrongPassword(length int) (string, error) {
+	if length < 8 {
+		return "", fmt.Errorf("strong passwords must be at least 8 characters long")
+	}
+
+	for {
+		password, err := GeneratePassword(length)
+		if err != nil {
+			return "", err
+		}
+		if IsStrongPassword(password) {
+			return password, nil
+		}
+	}
+}
+
+// IsStrongPassword reports whether a password has at least 8 characters and contains at
+// least three of the four character classes: upper case letters, lower case letters,
+// digits and symbols.
+func IsStrongPassword(password string) bool {
+	if len(password) < 8 {
+		return false
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{upper, lower, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes >= 3
+}
+
 // ValidateCreateInstanceOptions validates and sets defaults for CreateInstanceOptions.
 func ValidateCreateInstanceOptions(opts *CreateInstanceOptions) error {
 	// Validate database type
@@ -47,7 +97,36 @@ func ValidateCreateInstanceOptions(opts *CreateInstanceOptions) error {
 	}
 
 	if !opts.Type.IsValid() {
-		return fmt.Errorf("invalid database type: %s", opts.Type)
+		return fmt.Errorf("invalid database type: %s (supported: %s)", opts.Type, strings.Join(EngineTypeNames(), ", "))
+	}
+
+	if opts.TTL < 0 {
+		return fmt.Errorf("ttl must not be negative")
+	}
+
+	if opts.NoWait && len(opts.InitScripts) > 0 {
+		return fmt.Errorf("init scripts require waiting for the instance to become healthy")
+	}
+
+	engine, _ := LookupEngine(opts.Type)
+	if opts.NoWait && len(engine.SetupCommand) > 0 {
+		return fmt.Errorf("%s instances require waiting for the instance to become healthy", opts.Type)
+	}
+
+	if opts.Memory < 0 || opts.CPUs < 0 || opts.ShmSize < 0 {
+		return fmt.Errorf("resource limits must not be negative")
+	}
+
+	if opts.Memory != 0 && opts.Memory < MinMemoryLimit {
+		return fmt.Errorf("memory limit must be at least 6MiB")
+	}
+
+	if opts.Tmpfs && opts.Persistent {
+		return fmt.Errorf("tmpfs and persistent instances are mutually exclusive")
+	}
+
+	if opts.Tmpfs && engine.DataDir == "" {
+		return fmt.Errorf("%s instances do not support a tmpfs data directory", opts.Type)
 	}
 
 	// Set defaults based on database type
@@ -61,14 +140,22 @@ func ValidateCreateInstanceOptions(opts *CreateInstanceOptions) error {
 
 	if opts.Username == "" {
 		opts.Username = opts.Type.DefaultUsername()
+	} else if engine.FixedUsername && opts.Username != engine.DefaultUsername {
+		return fmt.Errorf("%s instances only support the %s user", opts.Type, engine.DefaultUsername)
 	}
 
 	if opts.Password == "" {
 		var err error
-		opts.Password, err = GeneratePassword(16)
+		if engine.StrongPassword {
+			opts.Password, err = GenerateStrongPassword(16)
+		} else {
+			opts.Password, err = GeneratePassword(16)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to generate password: %w", err)
 		}
+	} else if engine.StrongPassword && !IsStrongPassword(opts.Password) {
+		return fmt.Errorf("%s requires a password of at least 8 characters containing three of: upper case letters, lower case letters, digits and symbols", opts.Type)
 	}
 
 	return nil
@@ -76,36 +163,28 @@ func ValidateCreateInstanceOptions(opts *CreateInstanceOptions) error {
 
 // BuildDSN builds a Data Source Name (DSN) for the given database instance.
 func BuildDSN(instance *DatabaseInstance) string {
-	switch instance.Type {
-	case DatabaseTypePostgreSQL:
-		return fmt.Sprintf("postgres://%s:%s@localhost:%d/%s?sslmode=disable",
-			instance.Username, instance.Password, instance.Port, instance.Database)
-	case DatabaseTypeMySQL:
-		return fmt.Sprintf("%s:%s@tcp(localhost:%d)/%s",
-			instance.Username, instance.Password, instance.Port, instance.Database)
-	case DatabaseTypeMariaDB:
-		return fmt.Sprintf("%s:%s@tcp(localhost:%d)/%s",
-			instance.Username, instance.Password, instance.Port, instance.Database)
-	default:
+	d, ok := LookupEngine(instance.Type)
+	if !ok {
 		return ""
 	}
+	return d.BuildDSN(instance)
 }
 
 // GetDockerImage returns the Docker image name for the given database type and version.
 func GetDockerImage(dbType DatabaseType, version string) string {
-	switch dbType {
-	case DatabaseTypePostgreSQL:
-		return fmt.Sprintf("postgres:%s", version)
-	case DatabaseTypeMySQL:
-		return fmt.Sprintf("mysql:%s", version)
-	case DatabaseTypeMariaDB:
-		return fmt.Sprintf("mariadb:%s", version)
-	default:
+	d, ok := LookupEngine(dbType)
+	if !ok {
 		return ""
 	}
+	return fmt.Sprintf("%s:%s", d.Image, version)
 }
 
 // GetContainerName generates a container name for the given instance ID and database type.
 func GetContainerName(instanceID string, dbType DatabaseType) string {
 	return fmt.Sprintf("dev-%s-mcp-%s", dbType.String(), instanceID)
 }
+
+// GetVolumeName generates the data volume name for the given instance ID and database type.
+func GetVolumeName(instanceID string, dbType DatabaseType) string {
+	return fmt.Sprintf("%s-data", GetContainerName(instanceID, dbType))
+}
@@ -2,6 +2,8 @@ package integration_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"runtime"
@@ -107,6 +109,30 @@ func TestCLICommands(t *testing.T) {
 		c.Assert(string(output), qt.Contains, "not found")
 	})
 
+	c.Run("database_exec", func(c *qt.C) {
+		cmd := exec.Command(binaryName, "database", "create", "--format", "json", "--start-port", "21000", "--end-port", "21010")
+		output, err := cmd.Output()
+		c.Assert(err, qt.IsNil)
+		var instance struct {
+			ID string `json:"id"`
+		}
+		c.Assert(json.Unmarshal(output, &instance), qt.IsNil)
+		defer exec.Command(binaryName, "database", "drop", instance.ID, "--force", "--start-port", "21000", "--end-port", "21010").Run()
+
+		// The "--" separator is not part of the command run in the container
+		cmd = exec.Command(binaryName, "database", "exec", "--start-port", "21000", "--end-port", "21010", instance.ID, "--", "echo", "hello", "world")
+		output, err = cmd.CombinedOutput()
+		c.Assert(err, qt.IsNil, qt.Commentf("output: %s", output))
+		c.Assert(string(output), qt.Equals, "hello world\n")
+
+		// The exit code of the command is passed through
+		cmd = exec.Command(binaryName, "database", "exec", "--start-port", "21000", "--end-port", "21010", instance.ID, "--", "sh", "-c", "exit 3")
+		err = cmd.Run()
+		var exitErr *exec.ExitError
+		c.Assert(errors.As(err, &exitErr), qt.IsTrue)
+		c.Assert(exitErr.ExitCode(), qt.Equals, 3)
+	})
+
 	c.Run("mcp_serve_help", func(c *qt.C) {
 		cmd := exec.Command(binaryName, "mcp", "serve", "--help")
 		output, err := cmd.CombinedOutput()
@@ -201,6 +227,13 @@ func TestCLIErrorHandling(t *testing.T) {
 		c.Assert(outputStr, qt.Contains, "Available Commands")
 	})
 
+	c.Run("exec_missing_command", func(c *qt.C) {
+		cmd := exec.Command(binaryName, "database", "exec", "abc123", "--")
+		output, err := cmd.CombinedOutput()
+		c.Assert(err, qt.IsNotNil)
+		c.Assert(string(output), qt.Contains, "a command to run is required")
+	})
+
 	c.Run("invalid_flag", func(c *qt.C) {
 		cmd := exec.Command(binaryName, "database", "list", "--invalid-flag")
 		output, err := cmd.CombinedOutput()
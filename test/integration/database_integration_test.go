@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -62,6 +63,12 @@ func TestUnifiedDatabaseManager(t *testing.T) {
 		c.Assert(err, qt.IsNil)
 		c.Assert(health, qt.IsNotNil)
 		c.Assert(health.Status, qt.Not(qt.Equals), types.HealthStatusUnknown)
+		if health.Status == types.HealthStatusHealthy {
+			c.Assert(health.ContainerStatus, qt.Equals, "healthy")
+			c.Assert(health.Latency, qt.Not(qt.Equals), "")
+			c.Assert(health.ServerVersion, qt.Not(qt.Equals), "")
+			c.Assert(health.ActiveConnections, qt.IsNotNil)
+		}
 	})
 
 	t.Run("Create MySQL instance", func(t *testing.T) {
@@ -134,6 +141,78 @@ func TestUnifiedDatabaseManager(t *testing.T) {
 		c.Assert(health.Status, qt.Not(qt.Equals), types.HealthStatusUnknown)
 	})
 
+	t.Run("Create Redis, MongoDB, ClickHouse and CockroachDB instances", func(t *testing.T) {
+		for _, dbType := range []types.DatabaseType{types.DatabaseTypeRedis, types.DatabaseTypeMongoDB, types.DatabaseTypeClickHouse, types.DatabaseTypeCockroachDB} {
+			t.Run(string(dbType), func(t *testing.T) {
+				c := qt.New(t)
+
+				instance, err := unifiedManager.CreateInstance(ctx, types.CreateInstanceOptions{Type: dbType})
+				c.Assert(err, qt.IsNil)
+				c.Assert(instance.Type, qt.Equals, dbType)
+				c.Assert(instance.Version, qt.Equals, dbType.DefaultVersion())
+				c.Assert(instance.DSN, qt.Not(qt.Equals), "")
+
+				// Clean up
+				defer func() {
+					err := unifiedManager.DropInstance(ctx, instance.ID)
+					c.Assert(err, qt.IsNil)
+				}()
+
+				health, err := unifiedManager.HealthCheck(ctx, instance.ID)
+				c.Assert(err, qt.IsNil)
+				c.Assert(health.Status, qt.Equals, types.HealthStatusHealthy)
+			})
+		}
+	})
+
+	t.Run("Clone instance", func(t *testing.T) {
+		c := qt.New(t)
+
+		source, err := unifiedManager.CreateInstance(ctx, types.CreateInstanceOptions{Type: types.DatabaseTypePostgreSQL})
+		c.Assert(err, qt.IsNil)
+		defer func() {
+			c.Assert(unifiedManager.DropInstance(ctx, source.ID), qt.IsNil)
+		}()
+
+		_, err = unifiedManager.ExecuteSQL(ctx, source.ID, "CREATE TABLE items (id int); INSERT INTO items VALUES (1), (2)", types.QueryOptions{})
+		c.Assert(err, qt.IsNil)
+
+		clone, err := unifiedManager.CloneInstance(ctx, source.ID, types.CloneInstanceOptions{})
+		c.Assert(err, qt.IsNil)
+		defer func() {
+			c.Assert(unifiedManager.DropInstance(ctx, clone.ID), qt.IsNil)
+		}()
+		c.Assert(clone.ID, qt.Not(qt.Equals), source.ID)
+		c.Assert(clone.Port, qt.Not(qt.Equals), source.Port)
+		c.Assert(clone.Database, qt.Equals, source.Database)
+
+		result, err := unifiedManager.ExecuteSQL(ctx, clone.ID, "SELECT count(*) FROM items", types.QueryOptions{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(result.Rows, qt.HasLen, 1)
+		c.Assert(fmt.Sprint(result.Rows[0][0]), qt.Equals, "2")
+	})
+
+	t.Run("Clone engine without dumps", func(t *testing.T) {
+		c := qt.New(t)
+
+		source, err := unifiedManager.CreateInstance(ctx, types.CreateInstanceOptions{Type: types.DatabaseTypeRedis})
+		c.Assert(err, qt.IsNil)
+		defer func() {
+			c.Assert(unifiedManager.DropInstance(ctx, source.ID), qt.IsNil)
+		}()
+
+		before, err := unifiedManager.ListInstances(ctx)
+		c.Assert(err, qt.IsNil)
+
+		// The clone fails before a container is created
+		_, err = unifiedManager.CloneInstance(ctx, source.ID, types.CloneInstanceOptions{})
+		c.Assert(err, qt.ErrorMatches, "redis instances do not support cloning")
+
+		after, err := unifiedManager.ListInstances(ctx)
+		c.Assert(err, qt.IsNil)
+		c.Assert(after, qt.HasLen, len(before))
+	})
+
 	t.Run("List instances by type", func(t *testing.T) {
 		c := qt.New(t)
 
@@ -201,6 +280,37 @@ func TestUnifiedDatabaseManager(t *testing.T) {
 		c.Assert(retrieved.ID, qt.Equals, instance.ID)
 	})
 
+	t.Run("Get instances of other principals", func(t *testing.T) {
+		c := qt.New(t)
+
+		alice := types.ContextWithPrincipal(ctx, &types.Principal{Name: "alice"})
+		bob := types.ContextWithPrincipal(ctx, &types.Principal{Name: "bob"})
+
+		aliceInstance, err := unifiedManager.CreateInstance(alice, types.CreateInstanceOptions{Type: types.DatabaseTypeRedis})
+		c.Assert(err, qt.IsNil)
+		defer unifiedManager.DropInstance(ctx, aliceInstance.ID)
+		bobInstance, err := unifiedManager.CreateInstance(bob, types.CreateInstanceOptions{Type: types.DatabaseTypeRedis})
+		c.Assert(err, qt.IsNil)
+		defer unifiedManager.DropInstance(ctx, bobInstance.ID)
+
+		_, err = unifiedManager.GetInstance(bob, aliceInstance.ID)
+		c.Assert(err, qt.ErrorMatches, "instance .* not found")
+		_, err = unifiedManager.GetInstance(bob, aliceInstance.ID[:8])
+		c.Assert(err, qt.ErrorMatches, "instance .* not found")
+
+		// Every ID has the empty prefix, but only bob's instance is matched for bob
+		retrieved, err := unifiedManager.GetInstance(bob, "")
+		c.Assert(err, qt.IsNil)
+		c.Assert(retrieved.ID, qt.Equals, bobInstance.ID)
+		_, err = unifiedManager.GetInstance(ctx, "")
+		c.Assert(err, qt.ErrorMatches, "multiple instances match .*")
+
+		// Prefixes are resolved before the instance is dropped or checked
+		_, err = unifiedManager.HealthCheck(bob, bobInstance.ID[:8])
+		c.Assert(err, qt.IsNil)
+		c.Assert(unifiedManager.DropInstance(bob, bobInstance.ID[:8]), qt.IsNil)
+	})
+
 	t.Run("Drop instance", func(t *testing.T) {
 		c := qt.New(t)
 
@@ -224,6 +334,38 @@ func TestUnifiedDatabaseManager(t *testing.T) {
 		c.Assert(err, qt.IsNotNil)
 	})
 
+	t.Run("Start stopped instance over memory quota", func(t *testing.T) {
+		c := qt.New(t)
+
+		const mib = 1024 * 1024
+		config := database.DefaultManagerConfig()
+		config.Quotas = types.QuotaPolicy{MaxTotalMemory: 384 * mib}
+		quotaManager := database.NewUnifiedManagerWithConfig(dockerMgr, config)
+
+		stopped, err := quotaManager.CreateInstance(ctx, types.CreateInstanceOptions{Type: types.DatabaseTypePostgreSQL, Memory: 256 * mib})
+		c.Assert(err, qt.IsNil)
+		defer func() {
+			c.Assert(quotaManager.DropInstance(ctx, stopped.ID), qt.IsNil)
+		}()
+		c.Assert(quotaManager.StopInstance(ctx, stopped.ID), qt.IsNil)
+
+		// The stopped instance's memory is free for a new instance
+		running, err := quotaManager.CreateInstance(ctx, types.CreateInstanceOptions{Type: types.DatabaseTypePostgreSQL, Memory: 256 * mib})
+		c.Assert(err, qt.IsNil)
+		defer func() {
+			c.Assert(quotaManager.DropInstance(ctx, running.ID), qt.IsNil)
+		}()
+
+		// ... so the stopped instance no longer fits
+		err = quotaManager.StartInstance(ctx, stopped.ID)
+		c.Assert(err, qt.ErrorIs, types.ErrQuotaExceeded)
+		err = quotaManager.RestartInstance(ctx, stopped.ID)
+		c.Assert(err, qt.ErrorIs, types.ErrQuotaExceeded)
+
+		c.Assert(quotaManager.StopInstance(ctx, running.ID), qt.IsNil)
+		c.Assert(quotaManager.StartInstance(ctx, stopped.ID), qt.IsNil)
+	})
+
 	t.Run("Cleanup all instances", func(t *testing.T) {
 		c := qt.New(t)
 
@@ -0,0 +1,130 @@
+package integration_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/mark3labs/mcp-go/client"
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/internal/mcp"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+func TestMCPCreateProgress(t *testing.T) {
+	c := qt.New(t)
+
+	dockerMgr, err := docker.NewManager(20800, 20900)
+	if err != nil {
+		c.Skip("Docker not available:", err)
+	}
+	defer dockerMgr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := dockerMgr.Ping(ctx); err != nil {
+		c.Skip("Docker daemon not accessible:", err)
+	}
+
+	unifiedManager := database.NewUnifiedManager(dockerMgr)
+	toolHandler := mcp.NewToolHandler(unifiedManager)
+	mcpServer := server.NewMCPServer("dev-postgres-mcp-test", "test")
+	for _, tool := range toolHandler.GetTools() {
+		mcpServer.AddTool(tool, toolHandler.HandleTool)
+	}
+
+	// The streamable HTTP transport cancels a request's context when the client abandons it
+	httpServer := server.NewTestStreamableHTTPServer(mcpServer)
+	defer httpServer.Close()
+	mcpClient, err := client.NewStreamableHttpClient(httpServer.URL + "/mcp")
+	c.Assert(err, qt.IsNil)
+	defer mcpClient.Close()
+
+	var mu sync.Mutex
+	var progress []map[string]any
+	var onProgress func(map[string]any)
+	mcpClient.OnNotification(func(notification mcplib.JSONRPCNotification) {
+		if notification.Method != "notifications/progress" {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		progress = append(progress, notification.Params.AdditionalFields)
+		if onProgress != nil {
+			onProgress(notification.Params.AdditionalFields)
+		}
+	})
+	c.Assert(mcpClient.Start(ctx), qt.IsNil)
+	request := mcplib.InitializeRequest{}
+	request.Params.ProtocolVersion = mcplib.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcplib.Implementation{Name: "progress-test", Version: "test"}
+	_, err = mcpClient.Initialize(ctx, request)
+	c.Assert(err, qt.IsNil)
+
+	createRequest := func(token string) mcplib.CallToolRequest {
+		request := mcplib.CallToolRequest{}
+		request.Params.Name = "create_database_instance"
+		request.Params.Arguments = map[string]any{"type": "postgresql"}
+		request.Params.Meta = &mcplib.Meta{ProgressToken: token}
+		return request
+	}
+
+	t.Run("Progress notifications", func(t *testing.T) {
+		c := qt.New(t)
+
+		result, err := mcpClient.CallTool(ctx, createRequest("create"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(result.IsError, qt.IsFalse)
+		defer func() {
+			instances, _ := unifiedManager.ListInstances(ctx)
+			for _, instance := range instances {
+				_ = unifiedManager.DropInstance(ctx, instance.ID)
+			}
+		}()
+
+		mu.Lock()
+		defer mu.Unlock()
+		c.Assert(len(progress) >= 3, qt.IsTrue, qt.Commentf("progress: %v", progress))
+		last := 0.0
+		for _, update := range progress {
+			c.Assert(update["progressToken"], qt.Equals, "create")
+			c.Assert(update["total"], qt.Equals, float64(100))
+			value := update["progress"].(float64)
+			c.Assert(value >= last, qt.IsTrue, qt.Commentf("progress decreased: %v", progress))
+			last = value
+		}
+		c.Assert(last >= 60, qt.IsTrue, qt.Commentf("health polling not reported: %v", progress))
+	})
+
+	t.Run("Cancellation removes the container", func(t *testing.T) {
+		c := qt.New(t)
+
+		before, err := dockerMgr.ListContainersByType(ctx, types.DatabaseTypePostgreSQL)
+		c.Assert(err, qt.IsNil)
+
+		// Cancel the request once the container is started
+		callCtx, cancelCall := context.WithCancel(ctx)
+		defer cancelCall()
+		mu.Lock()
+		progress = nil
+		onProgress = func(update map[string]any) {
+			if update["progress"].(float64) >= 55 {
+				cancelCall()
+			}
+		}
+		mu.Unlock()
+
+		_, err = mcpClient.CallTool(callCtx, createRequest("cancelled"))
+		c.Assert(err, qt.IsNotNil)
+
+		waitFor(c, func() bool {
+			after, err := dockerMgr.ListContainersByType(ctx, types.DatabaseTypePostgreSQL)
+			return err == nil && len(after) == len(before)
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/mark3labs/mcp-go/client"
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/internal/mcp"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+func TestMCPInstanceResources(t *testing.T) {
+	c := qt.New(t)
+
+	dockerMgr, err := docker.NewManager(20700, 20800)
+	if err != nil {
+		c.Skip("Docker not available:", err)
+	}
+	defer dockerMgr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := dockerMgr.Ping(ctx); err != nil {
+		c.Skip("Docker daemon not accessible:", err)
+	}
+
+	unifiedManager := database.NewUnifiedManager(dockerMgr)
+	mcpServer := server.NewMCPServer("dev-postgres-mcp-test", "test", server.WithResourceCapabilities(false, true))
+	resources := mcp.NewResourceHandler(unifiedManager, mcpServer, time.Second)
+	go resources.Run(ctx)
+
+	// The in-process transport does not deliver notifications, so the client connects over SSE
+	httpServer := server.NewTestServer(mcpServer)
+	defer httpServer.Close()
+	mcpClient, err := client.NewSSEMCPClient(httpServer.URL + "/sse")
+	c.Assert(err, qt.IsNil)
+	defer mcpClient.Close()
+
+	var listChanged atomic.Int32
+	mcpClient.OnNotification(func(notification mcplib.JSONRPCNotification) {
+		if notification.Method == mcplib.MethodNotificationResourcesListChanged {
+			listChanged.Add(1)
+		}
+	})
+	c.Assert(mcpClient.Start(ctx), qt.IsNil)
+	request := mcplib.InitializeRequest{}
+	request.Params.ProtocolVersion = mcplib.LATEST_PROTOCOL_VERSION
+	request.Params.ClientInfo = mcplib.Implementation{Name: "resources-test", Version: "test"}
+	_, err = mcpClient.Initialize(ctx, request)
+	c.Assert(err, qt.IsNil)
+
+	templates, err := mcpClient.ListResourceTemplates(ctx, mcplib.ListResourceTemplatesRequest{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(templates.ResourceTemplates, qt.HasLen, 1)
+	c.Assert(templates.ResourceTemplates[0].URITemplate.Raw(), qt.Equals, mcp.TableResourceTemplate)
+
+	instance, err := unifiedManager.CreateInstance(ctx, types.CreateInstanceOptions{
+		Type:        types.DatabaseTypePostgreSQL,
+		InitScripts: []string{"CREATE TABLE users (id int PRIMARY KEY, email text);"},
+	})
+	c.Assert(err, qt.IsNil)
+	defer unifiedManager.DropInstance(ctx, instance.ID)
+
+	uri := "dbinstance://" + instance.ID
+	waitFor(c, func() bool { return hasResource(ctx, mcpClient, uri) })
+	c.Assert(listChanged.Load() > 0, qt.IsTrue)
+
+	// The instance resource has its details, connection information and schema summary
+	result, err := mcpClient.ReadResource(ctx, readResourceRequest(uri))
+	c.Assert(err, qt.IsNil)
+	var content struct {
+		Instance   types.DatabaseInstance `json:"instance"`
+		Connection struct {
+			Port int    `json:"port"`
+			DSN  string `json:"dsn"`
+		} `json:"connection"`
+		Schema struct {
+			Tables []struct {
+				Name string `json:"name"`
+				URI  string `json:"uri"`
+			} `json:"tables"`
+		} `json:"schema"`
+	}
+	c.Assert(json.Unmarshal([]byte(result.Contents[0].(mcplib.TextResourceContents).Text), &content), qt.IsNil)
+	c.Assert(content.Instance.ID, qt.Equals, instance.ID)
+	c.Assert(content.Connection.Port, qt.Equals, instance.Port)
+	c.Assert(content.Connection.DSN, qt.Equals, instance.DSN)
+	c.Assert(content.Schema.Tables, qt.HasLen, 1)
+	c.Assert(content.Schema.Tables[0].Name, qt.Equals, "users")
+
+	// The table resource lists the table's columns
+	result, err = mcpClient.ReadResource(ctx, readResourceRequest(content.Schema.Tables[0].URI))
+	c.Assert(err, qt.IsNil)
+	var table types.TableSchema
+	c.Assert(json.Unmarshal([]byte(result.Contents[0].(mcplib.TextResourceContents).Text), &table), qt.IsNil)
+	c.Assert(table.Columns, qt.HasLen, 2)
+	c.Assert(table.Columns[1].Name, qt.Equals, "email")
+	c.Assert(table.Columns[1].Nullable, qt.IsTrue)
+
+	// Dropping the instance removes its resource
+	c.Assert(unifiedManager.DropInstance(ctx, instance.ID), qt.IsNil)
+	waitFor(c, func() bool { return !hasResource(ctx, mcpClient, uri) })
+}
+
+// hasResource reports whether the server lists a resource with the URI.
+func hasResource(ctx context.Context, mcpClient *client.Client, uri string) bool {
+	result, err := mcpClient.ListResources(ctx, mcplib.ListResourcesRequest{})
+	if err != nil {
+		return false
+	}
+	for _, resource := range result.Resources {
+		if resource.URI == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// readResourceRequest returns a request reading the resource with the URI.
+func readResourceRequest(uri string) mcplib.ReadResourceRequest {
+	request := mcplib.ReadResourceRequest{}
+	request.Params.URI = uri
+	return request
+}
+
+// waitFor polls the condition until it holds or ten seconds pass.
+func waitFor(c *qt.C, condition func() bool) {
+	for range 100 {
+		if condition() {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Fatal("condition not met in time")
+}
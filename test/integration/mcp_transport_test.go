@@ -0,0 +1,109 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/mark3labs/mcp-go/client"
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/mcp"
+)
+
+func TestMCPServerTransports(t *testing.T) {
+	tests := []struct {
+		transport string
+		endpoint  string
+		newClient func(url string) (*client.Client, error)
+	}{
+		{mcp.TransportSSE, mcp.SSEEndpoint, func(url string) (*client.Client, error) { return client.NewSSEMCPClient(url) }},
+		{mcp.TransportHTTP, mcp.HTTPEndpoint, func(url string) (*client.Client, error) { return client.NewStreamableHttpClient(url) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transport, func(t *testing.T) {
+			c := qt.New(t)
+
+			addr := freeListenAddr(c)
+			server, err := mcp.NewServer(mcp.ServerConfig{
+				Name:       "dev-postgres-mcp-test",
+				Version:    "test",
+				StartPort:  20500,
+				EndPort:    20600,
+				Transport:  tt.transport,
+				ListenAddr: addr,
+			})
+			if err != nil {
+				c.Skip("Docker not available:", err)
+			}
+			defer server.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			served := make(chan error, 1)
+			go func() {
+				served <- server.Start(ctx)
+			}()
+
+			// Several clients can connect to the same server
+			for range 2 {
+				mcpClient := connectClient(c, ctx, func() (*client.Client, error) {
+					return tt.newClient("http://" + addr + tt.endpoint)
+				})
+
+				tools, err := mcpClient.ListTools(ctx, mcplib.ListToolsRequest{})
+				c.Assert(err, qt.IsNil)
+				c.Assert(len(tools.Tools), qt.Equals, 20)
+				prompts, err := mcpClient.ListPrompts(ctx, mcplib.ListPromptsRequest{})
+				c.Assert(err, qt.IsNil)
+				c.Assert(len(prompts.Prompts), qt.Equals, 3)
+				c.Assert(mcpClient.Close(), qt.IsNil)
+			}
+
+			// Cancelling the context shuts the server down gracefully
+			cancel()
+			select {
+			case err := <-served:
+				c.Assert(errors.Is(err, context.Canceled), qt.IsTrue, qt.Commentf("unexpected error: %v", err))
+			case <-time.After(mcp.ShutdownTimeout + 5*time.Second):
+				c.Fatal("server did not shut down")
+			}
+		})
+	}
+}
+
+// freeListenAddr returns a local address with a port that is currently free.
+func freeListenAddr(c *qt.C) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	addr := listener.Addr().String()
+	c.Assert(listener.Close(), qt.IsNil)
+	return addr
+}
+
+// connectClient creates, starts and initializes an MCP client, retrying while the server starts.
+func connectClient(c *qt.C, ctx context.Context, newClient func() (*client.Client, error)) *client.Client {
+	var lastErr error
+	for range 50 {
+		mcpClient, err := newClient()
+		c.Assert(err, qt.IsNil)
+
+		if lastErr = mcpClient.Start(ctx); lastErr == nil {
+			request := mcplib.InitializeRequest{}
+			request.Params.ProtocolVersion = mcplib.LATEST_PROTOCOL_VERSION
+			request.Params.ClientInfo = mcplib.Implementation{Name: "transport-test", Version: "test"}
+			if _, lastErr = mcpClient.Initialize(ctx, request); lastErr == nil {
+				return mcpClient
+			}
+		}
+		mcpClient.Close()
+		time.Sleep(100 * time.Millisecond)
+	}
+	c.Fatalf("failed to connect to the MCP server: %v", lastErr)
+	return nil
+}
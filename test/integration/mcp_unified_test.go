@@ -37,14 +37,29 @@ func TestUnifiedMCPTools(t *testing.T) {
 		c := qt.New(t)
 
 		tools := toolHandler.GetTools()
-		c.Assert(len(tools), qt.Equals, 5) // 5 unified tools
+		c.Assert(len(tools), qt.Equals, 20) // 20 unified tools
 
 		expectedTools := []string{
 			"create_database_instance",
 			"list_database_instances",
 			"get_database_instance",
 			"drop_database_instance",
+			"stop_database_instance",
+			"start_database_instance",
+			"restart_database_instance",
 			"health_check_database",
+			"list_database_volumes",
+			"detach_database_instance",
+			"attach_database_volume",
+			"delete_database_volume",
+			"clone_database_instance",
+			"snapshot_database_instance",
+			"restore_database_instance",
+			"list_database_snapshots",
+			"delete_database_snapshot",
+			"exec_in_instance",
+			"get_instance_logs",
+			"execute_sql",
 		}
 
 		toolNames := make([]string, len(tools))
@@ -168,6 +183,33 @@ func TestUnifiedMCPTools(t *testing.T) {
 		c.Assert(content, qt.Contains, instance.ID)
 	})
 
+	t.Run("Execute SQL tool", func(t *testing.T) {
+		c := qt.New(t)
+
+		// Create a test instance first
+		opts := types.CreateInstanceOptions{
+			Type:     types.DatabaseTypePostgreSQL,
+			Database: "sqltest",
+		}
+		instance, err := unifiedManager.CreateInstance(ctx, opts)
+		c.Assert(err, qt.IsNil)
+		defer unifiedManager.DropInstance(ctx, instance.ID)
+
+		result, err := callTool(ctx, toolHandler, "execute_sql", map[string]any{
+			"instance_id": instance.ID,
+			"sql":         "SELECT generate_series(1, $1) AS n",
+			"params":      []any{5},
+			"max_rows":    float64(3),
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(result.IsError, qt.IsFalse)
+
+		content := getTextContent(result, 0)
+		c.Assert(content, qt.Contains, `"row_count": 3`)
+		c.Assert(content, qt.Contains, `"truncated": true`)
+		c.Assert(content, qt.Contains, `"truncated_reason": "max_rows"`)
+	})
+
 	t.Run("Drop database instance tool", func(t *testing.T) {
 		c := qt.New(t)
 
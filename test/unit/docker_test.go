@@ -7,6 +7,7 @@ import (
 	qt "github.com/frankban/quicktest"
 
 	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
 )
 
 func TestPortManager(t *testing.T) {
@@ -169,6 +170,28 @@ func TestPortManagerGetAllocatedPorts(t *testing.T) {
 	c.Assert(allocated[0], qt.Equals, port2)
 }
 
+func TestPortManagerReservePort(t *testing.T) {
+	c := qt.New(t)
+
+	pm := docker.NewPortManager(17100, 17101)
+	ctx := context.Background()
+
+	// A reserved port (e.g. held by a stopped container) is never handed out
+	pm.ReservePort(17100)
+	c.Assert(pm.IsPortAllocated(17100), qt.IsTrue)
+
+	port, err := pm.AllocatePort(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(port, qt.Equals, 17101)
+
+	// Reserving an already allocated port is a no-op
+	pm.ReservePort(17101)
+	c.Assert(len(pm.GetAllocatedPorts()), qt.Equals, 2)
+
+	pm.ReleasePort(17100)
+	c.Assert(pm.IsPortAllocated(17100), qt.IsFalse)
+}
+
 func TestHealthStatus(t *testing.T) {
 	c := qt.New(t)
 
@@ -248,3 +271,29 @@ func TestDockerManagerPortAllocation(t *testing.T) {
 	// Release the port
 	manager.ReleasePort(port)
 }
+
+// TestClientExec tests the validation of exec requests.
+func TestClientExec(t *testing.T) {
+	c := qt.New(t)
+
+	client, err := docker.NewClient()
+	if err != nil {
+		c.Skip("Docker not available:", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	// An empty command is rejected without contacting the daemon
+	exitCode, err := client.Exec(ctx, "missing-container", types.ExecOptions{})
+	c.Assert(err, qt.ErrorMatches, "exec command must not be empty")
+	c.Assert(exitCode, qt.Equals, -1)
+
+	if err := client.Ping(ctx); err != nil {
+		c.Skip("Docker daemon not accessible:", err)
+	}
+
+	exitCode, err = client.Exec(ctx, "dev-postgres-mcp-missing-container", types.ExecOptions{Cmd: []string{"true"}})
+	c.Assert(err, qt.ErrorMatches, "failed to create exec in container dev-postgres-mcp-missing-container: .*")
+	c.Assert(exitCode, qt.Equals, -1)
+}
@@ -0,0 +1,79 @@
+package unit_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	mcplib "github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/database"
+	"github.com/stokaro/dev-postgres-mcp/internal/docker"
+	"github.com/stokaro/dev-postgres-mcp/internal/mcp"
+)
+
+func TestPromptHandler(t *testing.T) {
+	c := qt.New(t)
+
+	dockerMgr, err := docker.NewManager(19100, 19110)
+	if err != nil {
+		c.Skip("Docker not available:", err)
+	}
+	defer dockerMgr.Close()
+
+	handler := mcp.NewPromptHandler(database.NewUnifiedManager(dockerMgr))
+
+	getPrompt := func(name string, arguments map[string]string) (*mcplib.GetPromptResult, error) {
+		request := mcplib.GetPromptRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = arguments
+		return handler.HandlePrompt(t.Context(), request)
+	}
+
+	t.Run("Prompts", func(t *testing.T) {
+		c := qt.New(t)
+
+		prompts := handler.GetPrompts()
+		names := make([]string, 0, len(prompts))
+		for _, prompt := range prompts {
+			names = append(names, prompt.Name)
+		}
+		c.Assert(names, qt.DeepEquals, []string{"provision_test_database", "diagnose_slow_query", "compare_schemas"})
+		c.Assert(prompts[1].Arguments, qt.HasLen, 2)
+		c.Assert(prompts[1].Arguments[0].Required, qt.IsTrue)
+	})
+
+	t.Run("Provision test database", func(t *testing.T) {
+		c := qt.New(t)
+
+		result, err := getPrompt("provision_test_database", map[string]string{
+			"migration": "ALTER TABLE users ADD COLUMN age int;",
+			"type":      "mysql",
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(result.Messages, qt.HasLen, 1)
+		c.Assert(result.Messages[0].Role, qt.Equals, mcplib.RoleUser)
+		text := result.Messages[0].Content.(mcplib.TextContent).Text
+		c.Assert(text, qt.Contains, "ALTER TABLE users ADD COLUMN age int;")
+		c.Assert(text, qt.Contains, `create_database_instance (type "mysql", version "8.0"`)
+		c.Assert(text, qt.Contains, "with execute_sql")
+	})
+
+	t.Run("Missing and invalid arguments", func(t *testing.T) {
+		c := qt.New(t)
+
+		_, err := getPrompt("provision_test_database", map[string]string{})
+		c.Assert(err, qt.ErrorMatches, "migration argument is required")
+
+		_, err = getPrompt("provision_test_database", map[string]string{"migration": "x", "type": "oracle"})
+		c.Assert(err, qt.ErrorMatches, "unsupported database type: oracle")
+
+		_, err = getPrompt("diagnose_slow_query", map[string]string{"instance_id": "abc"})
+		c.Assert(err, qt.ErrorMatches, "instance_id and query arguments are required")
+
+		_, err = getPrompt("compare_schemas", map[string]string{"source_instance_id": "abc"})
+		c.Assert(err, qt.ErrorMatches, "source_instance_id and target_instance_id arguments are required")
+
+		_, err = getPrompt("unknown", nil)
+		c.Assert(err, qt.ErrorMatches, "unknown prompt: unknown")
+	})
+}
@@ -0,0 +1,234 @@
+package unit_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/stokaro/dev-postgres-mcp/internal/store"
+	"github.com/stokaro/dev-postgres-mcp/pkg/types"
+)
+
+func TestCredentialStore(t *testing.T) {
+	t.Run("Save and load", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewCredentialStore(t.TempDir())
+
+		password, err := s.LoadPassword("missing")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "")
+
+		c.Assert(s.SavePassword("abc123", "secret"), qt.IsNil)
+
+		password, err = s.LoadPassword("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "secret")
+
+		// A second store on the same directory sees the same entries
+		password, err = store.NewCredentialStore(s.Dir()).LoadPassword("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "secret")
+	})
+
+	t.Run("Stored data is encrypted", func(t *testing.T) {
+		c := qt.New(t)
+
+		dir := t.TempDir()
+		s := store.NewCredentialStore(dir)
+		c.Assert(s.SavePassword("abc123", "plaintext-password"), qt.IsNil)
+
+		raw, err := os.ReadFile(filepath.Join(dir, "credentials.enc"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(raw), qt.Not(qt.Contains), "plaintext-password")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewCredentialStore(t.TempDir())
+		c.Assert(s.DeletePassword("missing"), qt.IsNil)
+
+		c.Assert(s.SavePassword("abc123", "secret"), qt.IsNil)
+		c.Assert(s.DeletePassword("abc123"), qt.IsNil)
+
+		password, err := s.LoadPassword("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "")
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewCredentialStore(t.TempDir())
+		c.Assert(s.SavePassword("keep", "one"), qt.IsNil)
+		c.Assert(s.SavePassword("stale", "two"), qt.IsNil)
+
+		removed, err := s.Prune(map[string]bool{"keep": true})
+		c.Assert(err, qt.IsNil)
+		c.Assert(removed, qt.DeepEquals, []string{"stale"})
+
+		ids, err := s.InstanceIDs()
+		c.Assert(err, qt.IsNil)
+		c.Assert(ids, qt.DeepEquals, []string{"keep"})
+	})
+
+	t.Run("Rotate key", func(t *testing.T) {
+		c := qt.New(t)
+
+		dir := t.TempDir()
+		s := store.NewCredentialStore(dir)
+		c.Assert(s.SavePassword("abc123", "secret"), qt.IsNil)
+
+		oldKey, err := os.ReadFile(filepath.Join(dir, "credentials.key"))
+		c.Assert(err, qt.IsNil)
+
+		c.Assert(s.RotateKey(), qt.IsNil)
+
+		newKey, err := os.ReadFile(filepath.Join(dir, "credentials.key"))
+		c.Assert(err, qt.IsNil)
+		c.Assert(newKey, qt.Not(qt.DeepEquals), oldKey)
+
+		password, err := s.LoadPassword("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "secret")
+	})
+
+	t.Run("Interrupted key rotation", func(t *testing.T) {
+		c := qt.New(t)
+
+		dir := t.TempDir()
+		s := store.NewCredentialStore(dir)
+		c.Assert(s.SavePassword("abc123", "secret"), qt.IsNil)
+
+		keyPath := filepath.Join(dir, "credentials.key")
+		oldKey, err := os.ReadFile(keyPath)
+		c.Assert(err, qt.IsNil)
+		c.Assert(s.RotateKey(), qt.IsNil)
+		newKey, err := os.ReadFile(keyPath)
+		c.Assert(err, qt.IsNil)
+
+		// Simulate a crash after the data was re-encrypted but before the staged key was swapped in
+		c.Assert(os.WriteFile(keyPath+".new", newKey, 0o600), qt.IsNil)
+		c.Assert(os.WriteFile(keyPath, oldKey, 0o600), qt.IsNil)
+
+		password, err := store.NewCredentialStore(dir).LoadPassword("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(password, qt.Equals, "secret")
+
+		key, err := os.ReadFile(keyPath)
+		c.Assert(err, qt.IsNil)
+		c.Assert(key, qt.DeepEquals, newKey)
+		_, err = os.Stat(keyPath + ".new")
+		c.Assert(errors.Is(err, os.ErrNotExist), qt.IsTrue)
+	})
+
+	t.Run("Concurrent stores on the same directory", func(t *testing.T) {
+		c := qt.New(t)
+
+		dir := t.TempDir()
+		const writers = 20
+
+		var wg sync.WaitGroup
+		for i := range writers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Separate stores do not share the in-process mutex, like the server and a CLI call
+				s := store.NewCredentialStore(dir)
+				c.Check(s.SavePassword(fmt.Sprintf("instance-%d", i), "secret"), qt.IsNil)
+			}()
+		}
+		wg.Wait()
+
+		ids, err := store.NewCredentialStore(dir).InstanceIDs()
+		c.Assert(err, qt.IsNil)
+		c.Assert(ids, qt.HasLen, writers)
+	})
+}
+
+func TestSnapshotStore(t *testing.T) {
+	t.Run("Create, open and list", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewSnapshotStore(t.TempDir())
+
+		snapshots, err := s.List()
+		c.Assert(err, qt.IsNil)
+		c.Assert(snapshots, qt.HasLen, 0)
+
+		snapshot := &types.Snapshot{
+			ID:         "abc123",
+			InstanceID: "def456",
+			Type:       types.DatabaseTypePostgreSQL,
+			Method:     types.SnapshotMethodLogical,
+			CreatedAt:  time.Now().UTC(),
+		}
+		err = s.Create(snapshot, func(w io.Writer) error {
+			_, err := io.WriteString(w, "CREATE TABLE t (id int);")
+			return err
+		})
+		c.Assert(err, qt.IsNil)
+		c.Assert(snapshot.Size, qt.Equals, int64(24))
+
+		got, err := s.Get("abc")
+		c.Assert(err, qt.IsNil)
+		c.Assert(got.ID, qt.Equals, "abc123")
+		c.Assert(got.InstanceID, qt.Equals, "def456")
+
+		data, err := s.Open("abc123")
+		c.Assert(err, qt.IsNil)
+		defer data.Close()
+		content, err := io.ReadAll(data)
+		c.Assert(err, qt.IsNil)
+		c.Assert(string(content), qt.Equals, "CREATE TABLE t (id int);")
+
+		snapshots, err = s.List()
+		c.Assert(err, qt.IsNil)
+		c.Assert(snapshots, qt.HasLen, 1)
+	})
+
+	t.Run("Failed writes are not stored", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewSnapshotStore(t.TempDir())
+
+		err := s.Create(&types.Snapshot{ID: "abc123"}, func(w io.Writer) error {
+			return errors.New("dump failed")
+		})
+		c.Assert(err, qt.ErrorMatches, "dump failed")
+
+		snapshots, err := s.List()
+		c.Assert(err, qt.IsNil)
+		c.Assert(snapshots, qt.HasLen, 0)
+	})
+
+	t.Run("Invalid IDs are rejected", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewSnapshotStore(t.TempDir())
+		err := s.Create(&types.Snapshot{ID: "../escape"}, func(io.Writer) error { return nil })
+		c.Assert(err, qt.ErrorMatches, "invalid snapshot ID .*")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := qt.New(t)
+
+		s := store.NewSnapshotStore(t.TempDir())
+		c.Assert(s.Create(&types.Snapshot{ID: "abc123"}, func(io.Writer) error { return nil }), qt.IsNil)
+
+		deleted, err := s.Delete("abc123")
+		c.Assert(err, qt.IsNil)
+		c.Assert(deleted.ID, qt.Equals, "abc123")
+
+		_, err = s.Get("abc123")
+		c.Assert(err, qt.ErrorMatches, "snapshot abc123 not found")
+	})
+}